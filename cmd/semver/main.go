@@ -0,0 +1,161 @@
+// Command semver exposes the semver package to shell scripts.
+//
+// Usage:
+//
+//	semver compare V1 V2         print -1, 0, or 1 as V1 is lower than, equal to, or higher than V2
+//	semver sort                  read versions from stdin, one per line, and print them sorted
+//	semver validate V...         exit 0 if every version is valid, printing the invalid ones
+//	semver bump LEVEL V          print V bumped by LEVEL: major, minor, patch, or prerelease
+//	semver match CONSTRAINT V... print the versions satisfying CONSTRAINT, exit 1 if none does
+//
+// The exit status is 0 on success, 1 when a check such as validate or match fails, and 2
+// on a usage or parse error, so the result can drive a CI pipeline directly.
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/aboxofsox/semver"
+)
+
+// Exit statuses.
+const (
+	exitOK      = 0
+	exitFalse   = 1
+	exitFailure = 2
+)
+
+const usage = `usage:
+  semver compare V1 V2
+  semver sort < versions.txt
+  semver validate V...
+  semver bump major|minor|patch|prerelease V
+  semver match CONSTRAINT V...
+`
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run executes the command line args and returns the exit status.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return exitFailure
+	}
+
+	var err error
+	status := exitOK
+	switch cmd, args := args[0], args[1:]; {
+	case cmd == "compare" && len(args) == 2:
+		err = compare(args[0], args[1], stdout)
+	case cmd == "sort" && len(args) == 0:
+		err = sortLines(stdin, stdout)
+	case cmd == "validate" && len(args) > 0:
+		status = validate(args, stderr)
+	case cmd == "bump" && len(args) == 2:
+		err = bump(args[0], args[1], stdout)
+	case cmd == "match" && len(args) > 1:
+		status, err = match(args[0], args[1:], stdout)
+	default:
+		fmt.Fprint(stderr, usage)
+		return exitFailure
+	}
+
+	if err != nil {
+		fmt.Fprintln(stderr, "semver:", err)
+		return exitFailure
+	}
+	return status
+}
+
+func compare(v1, v2 string, stdout io.Writer) error {
+	result, err := semver.Compare(v1, v2)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(stdout, result)
+	return nil
+}
+
+// sortLines reads one version per line, skipping blank lines, and writes them in
+// ascending order of precedence.
+func sortLines(stdin io.Reader, stdout io.Writer) error {
+	var versions []string
+	scanner := bufio.NewScanner(stdin)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			versions = append(versions, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	sorted, err := semver.SortStrings(versions)
+	if err != nil {
+		return err
+	}
+	for _, v := range sorted {
+		fmt.Fprintln(stdout, v)
+	}
+	return nil
+}
+
+// validate reports every version that is not valid as semver.ParseStrict defines it.
+func validate(versions []string, stderr io.Writer) int {
+	status := exitOK
+	for _, v := range versions {
+		if _, err := semver.ParseStrict(v); err != nil {
+			fmt.Fprintln(stderr, "semver:", err)
+			status = exitFalse
+		}
+	}
+	return status
+}
+
+func bump(level, v string, stdout io.Writer) error {
+	ver, err := semver.ParseVersion(strings.TrimPrefix(v, "v"))
+	if err != nil {
+		return err
+	}
+
+	var next semver.Semver
+	switch level {
+	case "major":
+		next, err = ver.IncMajor()
+	case "minor":
+		next, err = ver.IncMinor()
+	case "patch":
+		next, err = ver.IncPatch()
+	case "prerelease":
+		next, err = ver.BumpPrerelease()
+	default:
+		err = fmt.Errorf("invalid level %q", level)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(stdout, next)
+	return nil
+}
+
+// match writes the versions that satisfy constraint and returns exitFalse when none
+// does.
+func match(constraint string, versions []string, stdout io.Writer) (int, error) {
+	matches, err := semver.FilterSatisfying(versions, constraint)
+	if err != nil {
+		return exitFailure, err
+	}
+	for _, v := range matches {
+		fmt.Fprintln(stdout, v)
+	}
+	if len(matches) == 0 {
+		return exitFalse, nil
+	}
+	return exitOK, nil
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		args   []string
+		stdin  string
+		stdout string
+		status int
+	}{
+		{[]string{"compare", "1.2.3", "1.3.0"}, "", "-1\n", exitOK},
+		{[]string{"compare", "v2.0.0", "1.3.0"}, "", "1\n", exitOK},
+		{[]string{"compare", "1.2.3", "latest"}, "", "", exitFailure},
+		{[]string{"sort"}, "v1.10.0\n\n1.2.0\n1.10.0-rc.1\n", "1.2.0\n1.10.0-rc.1\nv1.10.0\n", exitOK},
+		{[]string{"sort"}, "1.2.0\nlatest\n", "", exitFailure},
+		{[]string{"validate", "1.0.0-rc.1", "1.2.3+build.5"}, "", "", exitOK},
+		{[]string{"validate", "1.0.0", "1.02.0"}, "", "", exitFalse},
+		{[]string{"bump", "minor", "1.2.3"}, "", "1.3.0\n", exitOK},
+		{[]string{"bump", "major", "v1.2.3-rc.1"}, "", "2.0.0\n", exitOK},
+		{[]string{"bump", "prerelease", "1.0.0-rc.1"}, "", "1.0.0-rc.2\n", exitOK},
+		{[]string{"bump", "weekly", "1.2.3"}, "", "", exitFailure},
+		{[]string{"bump", "patch", "1.2"}, "", "", exitFailure},
+		{[]string{"match", "^1.2", "1.4.0"}, "", "1.4.0\n", exitOK},
+		{[]string{"match", "^1.2", "1.1.0", "1.9.0", "2.0.0"}, "", "1.9.0\n", exitOK},
+		{[]string{"match", "^1.2", "2.0.0"}, "", "", exitFalse},
+		{[]string{"match", ">>1", "2.0.0"}, "", "", exitFailure},
+		{nil, "", "", exitFailure},
+		{[]string{"compare", "1.2.3"}, "", "", exitFailure},
+		{[]string{"frobnicate"}, "", "", exitFailure},
+	}
+
+	for _, test := range tests {
+		var stdout, stderr bytes.Buffer
+		status := run(test.args, strings.NewReader(test.stdin), &stdout, &stderr)
+		if status != test.status {
+			t.Errorf("expected %q to exit with %d but got %d (stderr %q)", test.args, test.status, status, stderr.String())
+		}
+		if stdout.String() != test.stdout {
+			t.Errorf("expected %q to print %q but got %q", test.args, test.stdout, stdout.String())
+		}
+		if status == exitFailure && stderr.Len() == 0 {
+			t.Errorf("expected %q to explain the failure on stderr", test.args)
+		}
+	}
+}
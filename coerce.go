@@ -0,0 +1,123 @@
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Coerced records what Coerce had to infer or discard to turn its input into a version.
+// The zero value means the input was already a complete version, apart from surrounding
+// whitespace or a "v" prefix.
+type Coerced uint8
+
+const (
+	// CoercedPrefix means text before the version was dropped, as in "release-2.4".
+	CoercedPrefix Coerced = 1 << iota
+	// CoercedMinor means the minor component was missing and set to 0, as in "v1".
+	CoercedMinor
+	// CoercedPatch means the patch component was missing and set to 0, as in "1.2".
+	CoercedPatch
+	// CoercedPrerelease means a prerelease tag glued to the last component was split
+	// off, as in "go1.21rc2".
+	CoercedPrerelease
+	// CoercedSuffix means text after the version was dropped, such as the fourth
+	// component of "1.2.3.4".
+	CoercedSuffix
+)
+
+// Has reports whether flag is set in c.
+func (c Coerced) Has(flag Coerced) bool {
+	return c&flag != 0
+}
+
+// coerceRe matches the first run of one to three dot-separated numbers.
+var coerceRe = regexp.MustCompile(`(\d+)(?:\.(\d+))?(?:\.(\d+))?`)
+
+// gluedRe matches a prerelease tag written directly after a number, such as "rc2".
+var gluedRe = regexp.MustCompile(`^([A-Za-z]+)(\d*)`)
+
+// Coerce makes a best-effort version out of a partial or decorated version string, such
+// as those published by vendors who do not follow semantic versioning.
+//
+// The first run of up to three dot-separated numbers becomes the major, minor, and patch
+// components, with missing components set to 0, so "v1" is 1.0.0 and "release-2.4" is
+// 2.4.0. A prerelease tag and metadata following a "-" or "+" are kept when they are
+// well formed, and letters glued to the last number become the prerelease tag, so
+// "go1.21rc2" is 1.21.0-rc.2. Any other trailing text, such as the fourth component of
+// "1.2.3.4", is dropped.
+//
+// The returned Coerced reports what was inferred or dropped, so callers can log or
+// reject inputs that needed more than they are willing to guess.
+//
+// If v contains no number, or a component overflows, the function returns an empty
+// Semver structure, no flags, and an error.
+//
+// Example:
+//
+//	ver, coerced, err := Coerce("go1.21rc2")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(ver, coerced.Has(CoercedPatch)) // prints 1.21.0-rc.2 true
+func Coerce(v string) (Semver, Coerced, error) {
+	s := strings.TrimSpace(v)
+	loc := coerceRe.FindStringSubmatchIndex(s)
+	if loc == nil {
+		return Semver{}, 0, fmt.Errorf("no version found in %q", v)
+	}
+
+	var coerced Coerced
+	if prefix := s[:loc[0]]; prefix != "" && prefix != "v" && prefix != "V" {
+		coerced |= CoercedPrefix
+	}
+
+	parts := make([]int, 3)
+	for i := range parts {
+		start, end := loc[2+2*i], loc[3+2*i]
+		if start < 0 {
+			if i == 1 {
+				coerced |= CoercedMinor
+			} else {
+				coerced |= CoercedPatch
+			}
+			continue
+		}
+		n, err := strconv.Atoi(s[start:end])
+		if err != nil {
+			return Semver{}, 0, fmt.Errorf("invalid version %q: %w", v, err)
+		}
+		parts[i] = n
+	}
+	ver := Semver{Major: parts[0], Minor: parts[1], Patch: parts[2]}
+
+	rest := s[loc[1]:]
+	switch {
+	case rest == "":
+	case rest[0] == '-' || rest[0] == '+':
+		pre, meta, hasMeta := strings.Cut(rest, "+")
+		pre, hasPre := strings.CutPrefix(pre, "-")
+		if (hasPre && pre == "") || (hasMeta && meta == "") ||
+			validIdentifiers(pre, false) != nil || validIdentifiers(meta, false) != nil {
+			coerced |= CoercedSuffix
+			break
+		}
+		ver.Prerelease, ver.Meta = pre, meta
+	default:
+		m := gluedRe.FindStringSubmatch(rest)
+		if m == nil {
+			coerced |= CoercedSuffix
+			break
+		}
+		ver.Prerelease = m[1]
+		if m[2] != "" {
+			ver.Prerelease += "." + m[2]
+		}
+		coerced |= CoercedPrerelease
+		if len(m[0]) < len(rest) {
+			coerced |= CoercedSuffix
+		}
+	}
+	return ver, coerced, nil
+}
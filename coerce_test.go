@@ -0,0 +1,49 @@
+package semver
+
+import "testing"
+
+func TestCoerce(t *testing.T) {
+	tests := []struct {
+		v        string
+		expected string
+		coerced  Coerced
+	}{
+		{"1.2.3", "1.2.3", 0},
+		{" v1.2.3-rc.1+build.5 ", "1.2.3-rc.1+build.5", 0},
+		{"v1", "1.0.0", CoercedMinor | CoercedPatch},
+		{"1.2", "1.2.0", CoercedPatch},
+		{"release-2.4", "2.4.0", CoercedPrefix | CoercedPatch},
+		{"1.2.3.4", "1.2.3", CoercedSuffix},
+		{"go1.21rc2", "1.21.0-rc.2", CoercedPrefix | CoercedPatch | CoercedPrerelease},
+		{"go1.21.3", "1.21.3", CoercedPrefix},
+		{"2.0beta", "2.0.0-beta", CoercedPatch | CoercedPrerelease},
+		{"1.2-beta.1", "1.2.0-beta.1", CoercedPatch},
+		{"1.2.3-", "1.2.3", CoercedSuffix},
+		{"1.2.3-rc_1", "1.2.3", CoercedSuffix},
+		{"1.2.3_final", "1.2.3", CoercedSuffix},
+		{"openssl-3.0.2k-fips", "3.0.2-k", CoercedPrefix | CoercedPrerelease | CoercedSuffix},
+	}
+
+	for _, test := range tests {
+		ver, coerced, err := Coerce(test.v)
+		if err != nil {
+			t.Error(err)
+		}
+		if result := ver.String(); result != test.expected {
+			t.Errorf("expected %q to coerce to %s but got %s", test.v, test.expected, result)
+		}
+		if coerced != test.coerced {
+			t.Errorf("expected %q to report %05b but got %05b", test.v, test.coerced, coerced)
+		}
+	}
+
+	for _, v := range []string{"", "latest", "1.99999999999999999999"} {
+		if _, _, err := Coerce(v); err == nil {
+			t.Errorf("expected an error for %q", v)
+		}
+	}
+
+	if c := CoercedPrefix | CoercedPatch; !c.Has(CoercedPatch) || c.Has(CoercedMinor) {
+		t.Errorf("unexpected flags %05b", c)
+	}
+}
@@ -0,0 +1,467 @@
+package semver
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TotalCompare compares two version strings like Compare, but never reports two
+// different inputs as equal.
+//
+// When Compare considers the versions equal, the function breaks the tie
+// deterministically: the text preceding the version (such as a "v" prefix) is compared
+// first, so a prefix-less version sorts before a prefixed one, and the raw strings are
+// compared last. This keeps sorts reproducible when mixing tag styles. Compare itself
+// keeps treating such inputs as equal.
+//
+// If there is an error parsing either version string, the function returns 0 and the error.
+//
+// Example:
+//
+//	result, err := TotalCompare("1.2.3", "v1.2.3")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(result) // prints -1
+func TotalCompare(v1, v2 string) (int, error) {
+	result, err := Compare(v1, v2)
+	if err != nil || result != 0 {
+		return result, err
+	}
+
+	if result := strings.Compare(prefix(v1), prefix(v2)); result != 0 {
+		return result, nil
+	}
+
+	return strings.Compare(v1, v2), nil
+}
+
+// CompareWithPriority compares two version strings like Compare, but breaks ties between
+// versions of equal precedence by their position in priority: the string that appears
+// earlier wins and sorts first. Strings missing from priority sort after those in it,
+// and two missing strings stay equal. Strings are matched exactly as given.
+//
+// If there is an error parsing either version string, the function returns 0 and the error.
+//
+// Example:
+//
+//	priority := []string{"1.2.3+release", "1.2.3+nightly"}
+//	result, err := CompareWithPriority("1.2.3+nightly", "1.2.3+release", priority)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(result) // prints 1
+func CompareWithPriority(v1, v2 string, priority []string) (int, error) {
+	result, err := Compare(v1, v2)
+	if err != nil || result != 0 {
+		return result, err
+	}
+
+	rank1, rank2 := priorityRank(v1, priority), priorityRank(v2, priority)
+	return compareInts(rank1, rank2), nil
+}
+
+// priorityRank returns the index of v in priority, or len(priority) when it is missing.
+func priorityRank(v string, priority []string) int {
+	for i, p := range priority {
+		if p == v {
+			return i
+		}
+	}
+	return len(priority)
+}
+
+// prefix returns the text preceding the version found in v.
+func prefix(v string) string {
+	start, _ := findVersion(v)
+	if start < 0 {
+		return ""
+	}
+	return v[:start]
+}
+
+// CompareEpsilon compares two version strings like Compare, but treats versions of the
+// same major and minor line whose patch numbers differ by at most patchEpsilon as equal.
+// This groups nearby patch releases together in analytics. Prerelease tags are ignored
+// within the tolerance, and versions outside it compare as usual.
+//
+// If there is an error parsing either version string, or patchEpsilon is negative, the
+// function returns 0 and the error.
+//
+// Example:
+//
+//	result, err := CompareEpsilon("1.2.3", "1.2.5", 2)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(result) // prints 0
+func CompareEpsilon(v1, v2 string, patchEpsilon int) (int, error) {
+	if patchEpsilon < 0 {
+		return 0, fmt.Errorf("negative patch epsilon %d", patchEpsilon)
+	}
+	ver1, err := parse(v1)
+	if err != nil {
+		return 0, err
+	}
+	ver2, err := parse(v2)
+	if err != nil {
+		return 0, err
+	}
+
+	if ver1.Major == ver2.Major && ver1.Minor == ver2.Minor && absDiff(ver1.Patch, ver2.Patch) <= patchEpsilon {
+		return 0, nil
+	}
+	return compare(ver1, ver2), nil
+}
+
+// CompareOrString compares two strings that may or may not be versions, for sorting
+// mixed data robustly.
+//
+// When both strings parse as versions they are compared like Compare. Otherwise every
+// version sorts before every string that is not one, and two non-versions are compared
+// lexically with strings.Compare. The function never fails.
+//
+// Example:
+//
+//	fmt.Println(CompareOrString("1.2.0", "latest")) // prints -1
+func CompareOrString(a, b string) int {
+	ver1, err1 := parse(a)
+	ver2, err2 := parse(b)
+	switch {
+	case err1 == nil && err2 == nil:
+		return compare(ver1, ver2)
+	case err1 == nil:
+		return -1
+	case err2 == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// SameArtifact reports whether two version strings refer to the same artifact once
+// cosmetic differences are ignored.
+//
+// A leading "v" or other prefix, build metadata, and zero-padded numeric components do
+// not make two versions different. The major, minor, and patch components and the
+// prerelease tag must all match.
+//
+// If there is an error parsing either version string, the function returns false and the
+// error.
+//
+// Example:
+//
+//	same, err := SameArtifact("v1.2.3", "1.2.3+meta")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(same) // prints true
+func SameArtifact(a, b string) (bool, error) {
+	ver1, err := parse(a)
+	if err != nil {
+		return false, err
+	}
+	ver2, err := parse(b)
+	if err != nil {
+		return false, err
+	}
+
+	return compareCore(ver1, ver2) == 0 && ver1.Prerelease == ver2.Prerelease, nil
+}
+
+// CanonicalEqual reports whether two version strings have byte-identical canonical
+// forms, as produced by Canonical.
+//
+// This is stricter than comparing precedence with Compare, which ignores metadata, but
+// still ignores spelling: a "v" prefix or zero-padded numeric components do not make two
+// versions different. It is the right notion for deduplicating versions that may be
+// written differently.
+//
+// If there is an error parsing either version string, the function returns false and the
+// error.
+//
+// Example:
+//
+//	same, err := CanonicalEqual("v1.2.3+build.1", "1.2.3+build.2")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(same) // prints false
+func CanonicalEqual(v1, v2 string) (bool, error) {
+	c1, err := Canonical(v1)
+	if err != nil {
+		return false, err
+	}
+	c2, err := Canonical(v2)
+	if err != nil {
+		return false, err
+	}
+	return c1 == c2, nil
+}
+
+// CompareStage compares two version strings by prerelease stage only.
+//
+// The stage of a version is its PrereleaseBase. Known stages are ranked
+// alpha < beta < rc, unknown stages sort lexicographically after the known ones, and a
+// release without a prerelease tag ranks above every stage. Numeric suffixes and the
+// major, minor, and patch components are ignored, so "1.0.0-beta.9" and "2.0.0-beta.1"
+// are considered equal.
+//
+// If there is an error parsing either version string, the function returns 0 and the error.
+//
+// Example:
+//
+//	result, err := CompareStage("1.0.0-alpha.5", "1.0.0-rc.1")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(result) // prints -1
+func CompareStage(v1, v2 string) (int, error) {
+	ver1, err := parse(v1)
+	if err != nil {
+		return 0, err
+	}
+	ver2, err := parse(v2)
+	if err != nil {
+		return 0, err
+	}
+
+	s1, s2 := ver1.PrereleaseBase(), ver2.PrereleaseBase()
+	switch {
+	case ver1.Prerelease == "" && ver2.Prerelease == "":
+		return 0, nil
+	case ver1.Prerelease == "":
+		return 1, nil
+	case ver2.Prerelease == "":
+		return -1, nil
+	}
+
+	r1, r2 := stageRank(s1), stageRank(s2)
+	switch {
+	case r1 >= 0 && r2 >= 0:
+		return compareInts(r1, r2), nil
+	case r1 >= 0:
+		return -1, nil
+	case r2 >= 0:
+		return 1, nil
+	}
+
+	return strings.Compare(s1, s2), nil
+}
+
+// CompareOptions adjusts how CompareWith orders versions. The zero value applies the
+// standard rules of Compare.
+type CompareOptions struct {
+	// Normalizer, when set, rewrites both version strings before they are parsed, which
+	// lets callers handle bespoke input formats such as a "release/" prefix. The standard
+	// normalization still runs on its result.
+	Normalizer func(string) string
+
+	// IgnorePrereleaseIdentifiers lists prerelease identifiers that are dropped before
+	// comparing, along with any numeric identifiers that directly follow them. Only
+	// leading identifiers are dropped, so with "ci" ignored "1.0.0-ci.12345" compares as
+	// 1.0.0 and "1.0.0-ci.7.rc.1" compares as 1.0.0-rc.1.
+	IgnorePrereleaseIdentifiers []string
+
+	// PrereleaseSynonyms maps leading prerelease identifiers to the identifier they should
+	// compare as, so with "preview" mapped to "rc" "1.0.0-preview.1" compares equal to
+	// 1.0.0-rc.1. Synonyms are applied after IgnorePrereleaseIdentifiers and before
+	// ReleaseEquivalentPrerelease.
+	PrereleaseSynonyms map[string]string
+
+	// ReleaseEquivalentPrerelease lists prerelease tags that are treated as the release
+	// itself, so with "final" listed "1.2.3-final" compares equal to 1.2.3. Only a
+	// prerelease tag consisting solely of a listed token is affected: "1.2.3-final.1"
+	// still ranks below 1.2.3.
+	ReleaseEquivalentPrerelease []string
+
+	// IdentifierComparator overrides how two individual prerelease identifiers are
+	// compared. It must return a negative number, zero, or a positive number when a is
+	// less than, equal to, or greater than b. When nil, the standard rules apply.
+	IdentifierComparator func(a, b string) int
+
+	// CommitCountMeta enables a non-standard tiebreak for versions of equal precedence
+	// whose metadata carries a commit count, such as "+r42" with CommitCountMeta set to
+	// "r". The counts are compared numerically. When either version has no metadata
+	// identifier made of the prefix followed by digits, the tiebreak is skipped.
+	CommitCountMeta string
+
+	// BuildOutranksRelease enables a non-standard tiebreak for diagnosing build pipelines:
+	// of two versions of equal precedence where only one carries metadata, the one with
+	// metadata is greater, so 1.0.0+build ranks above 1.0.0. It runs before the other
+	// metadata tiebreaks.
+	BuildOutranksRelease bool
+
+	// MetaTiebreak enables a non-standard tiebreak that compares the metadata of versions
+	// of equal precedence, after any CommitCountMeta tiebreak. Metadata is compared with
+	// MetaComparator, or lexically when it is nil.
+	MetaTiebreak bool
+
+	// MetaComparator overrides how metadata is compared when MetaTiebreak is set. It must
+	// return a negative number, zero, or a positive number when a is less than, equal to,
+	// or greater than b.
+	MetaComparator func(a, b string) int
+
+	// ZeroIsLowest treats 0.0.0, a common placeholder for unknown or development builds,
+	// as lower than every other version, including prereleases of 0.0.0 such as
+	// 0.0.0-alpha. Metadata does not affect the check, so 0.0.0+dev is a placeholder too.
+	ZeroIsLowest bool
+}
+
+// CompareWith compares two version strings like Compare, adjusted by opts.
+//
+// If there is an error parsing either version string, the function returns 0 and the error.
+//
+// Example:
+//
+//	opts := CompareOptions{IgnorePrereleaseIdentifiers: []string{"ci"}}
+//	result, err := CompareWith("1.0.0-ci.5", "1.0.0", opts)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(result) // prints 0
+func CompareWith(v1, v2 string, opts CompareOptions) (int, error) {
+	if opts.Normalizer != nil {
+		v1, v2 = opts.Normalizer(v1), opts.Normalizer(v2)
+	}
+
+	ver1, err := parse(v1)
+	if err != nil {
+		return 0, err
+	}
+	ver2, err := parse(v2)
+	if err != nil {
+		return 0, err
+	}
+
+	return opts.compare(ver1, ver2), nil
+}
+
+// compare orders two parsed versions according to the options.
+func (opts CompareOptions) compare(ver1, ver2 Semver) int {
+	cmp := opts.IdentifierComparator
+	if cmp == nil {
+		cmp = compareIdentifier
+	}
+	pre1, pre2 := opts.prepare(ver1), opts.prepare(ver2)
+
+	if opts.ZeroIsLowest {
+		zero1, zero2 := pre1.key() == Semver{}, pre2.key() == Semver{}
+		switch {
+		case zero1 && !zero2:
+			return -1
+		case !zero1 && zero2:
+			return 1
+		}
+	}
+
+	if result := compareBy(pre1, pre2, cmp); result != 0 {
+		return result
+	}
+
+	if opts.BuildOutranksRelease {
+		switch {
+		case ver1.Meta != "" && ver2.Meta == "":
+			return 1
+		case ver1.Meta == "" && ver2.Meta != "":
+			return -1
+		}
+	}
+
+	if opts.CommitCountMeta != "" {
+		n1, ok1 := metaNumber(ver1.Meta, opts.CommitCountMeta)
+		n2, ok2 := metaNumber(ver2.Meta, opts.CommitCountMeta)
+		if ok1 && ok2 {
+			if result := compareInts(n1, n2); result != 0 {
+				return result
+			}
+		}
+	}
+
+	if opts.MetaTiebreak {
+		cmp := opts.MetaComparator
+		if cmp == nil {
+			cmp = strings.Compare
+		}
+		return cmp(ver1.Meta, ver2.Meta)
+	}
+
+	return 0
+}
+
+// prepare rewrites v according to the options before it is compared.
+func (opts CompareOptions) prepare(v Semver) Semver {
+	if len(opts.IgnorePrereleaseIdentifiers) > 0 && v.Prerelease != "" {
+		v.Prerelease = dropLeadingIdentifiers(v.Prerelease, opts.IgnorePrereleaseIdentifiers)
+	}
+	if len(opts.PrereleaseSynonyms) > 0 && v.Prerelease != "" {
+		first, rest, found := strings.Cut(v.Prerelease, ".")
+		if canonical, ok := opts.PrereleaseSynonyms[first]; ok {
+			v.Prerelease = canonical
+			if found {
+				v.Prerelease += "." + rest
+			}
+		}
+	}
+	if contains(opts.ReleaseEquivalentPrerelease, v.Prerelease) {
+		v.Prerelease = ""
+	}
+	return v
+}
+
+// dropLeadingIdentifiers removes leading identifiers of pre that appear in ignore,
+// together with the numeric identifiers that follow each of them.
+func dropLeadingIdentifiers(pre string, ignore []string) string {
+	idents := strings.Split(pre, ".")
+	for len(idents) > 0 && contains(ignore, idents[0]) {
+		idents = idents[1:]
+		for len(idents) > 0 && isNumeric(idents[0]) {
+			idents = idents[1:]
+		}
+	}
+	return strings.Join(idents, ".")
+}
+
+// contains reports whether list holds s.
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// CompareWarn compares two version strings like Compare and also returns a warning when
+// the comparison crosses a breaking 0.x minor boundary.
+//
+// Before 1.0.0, a minor bump may contain breaking changes, so comparing 0.1.0 with 0.2.0
+// produces a warning while comparing 1.1.0 with 1.2.0 does not. The warning is empty when
+// there is nothing to report.
+//
+// If there is an error parsing either version string, the function returns 0, an empty
+// warning, and the error.
+//
+// Example:
+//
+//	result, warning, err := CompareWarn("0.1.0", "0.2.0")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(result, warning) // prints -1 0.1.0 and 0.2.0 are on different 0.x minor lines, which may not be compatible
+func CompareWarn(v1, v2 string) (int, string, error) {
+	ver1, err := parse(v1)
+	if err != nil {
+		return 0, "", err
+	}
+	ver2, err := parse(v2)
+	if err != nil {
+		return 0, "", err
+	}
+
+	var warning string
+	if ver1.Major == 0 && ver2.Major == 0 && ver1.Minor != ver2.Minor {
+		warning = fmt.Sprintf("%s and %s are on different 0.x minor lines, which may not be compatible", ver1, ver2)
+	}
+
+	return compare(ver1, ver2), warning, nil
+}
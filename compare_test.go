@@ -0,0 +1,506 @@
+package semver
+
+import (
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestTotalCompare(t *testing.T) {
+	tests := []testCase{
+		{"1.2.3", "v1.2.3", -1},
+		{"v1.2.3", "1.2.3", 1},
+		{"v1.2.3", "v1.2.3", 0},
+		{"1.2.3+a", "1.2.3+b", -1},
+		{"v1.2.3", "1.2.4", -1},
+		{"release-1.2.3", "v1.2.3", -1},
+	}
+
+	for _, test := range tests {
+		c, err := TotalCompare(test.v1, test.v2)
+		if err != nil {
+			t.Error(err)
+		}
+		if c != test.expected {
+			t.Errorf("expected %s and %s to be %d but got %d", test.v1, test.v2, test.expected, c)
+		}
+	}
+
+	if c, _ := Compare("1.2.3", "v1.2.3"); c != 0 {
+		t.Errorf("expected Compare to keep treating prefixed versions as equal but got %d", c)
+	}
+}
+
+func TestCompareWithPriority(t *testing.T) {
+	priority := []string{"1.2.3+release", "1.2.3+nightly"}
+
+	tests := []struct {
+		v1       string
+		v2       string
+		expected int
+	}{
+		{"1.2.3+nightly", "1.2.3+release", 1},
+		{"1.2.3+release", "1.2.3+nightly", -1},
+		{"1.2.3+release", "1.2.3+local", -1},
+		{"1.2.3+local", "1.2.3+dev", 0},
+		{"1.2.3+nightly", "1.2.4", -1},
+		{"1.2.3+release", "1.2.3+release", 0},
+	}
+
+	for _, test := range tests {
+		c, err := CompareWithPriority(test.v1, test.v2, priority)
+		if err != nil {
+			t.Error(err)
+		}
+		if c != test.expected {
+			t.Errorf("expected %s and %s to be %d but got %d", test.v1, test.v2, test.expected, c)
+		}
+	}
+
+	if _, err := CompareWithPriority("1.2", "1.2.3", priority); err == nil {
+		t.Error("expected an error for an invalid version")
+	}
+}
+
+func TestSameArtifact(t *testing.T) {
+	tests := []struct {
+		a        string
+		b        string
+		expected bool
+	}{
+		{"v1.2.3", "1.2.3+meta", true},
+		{"1.02.3", "1.2.3", true},
+		{"1.2.3-rc.1+a", "v1.2.3-rc.1+b", true},
+		{"1.2.3-rc.1", "1.2.3", false},
+		{"1.2.3", "1.2.4", false},
+	}
+
+	for _, test := range tests {
+		same, err := SameArtifact(test.a, test.b)
+		if err != nil {
+			t.Error(err)
+		}
+		if same != test.expected {
+			t.Errorf("expected %s and %s to be same=%t but got %t", test.a, test.b, test.expected, same)
+		}
+	}
+}
+
+func TestCompareStage(t *testing.T) {
+	tests := []testCase{
+		{"1.0.0-alpha", "1.0.0-rc", -1},
+		{"1.0.0-rc.1", "1.0.0-alpha.7", 1},
+		{"1.0.0-beta.9", "2.0.0-beta.1", 0},
+		{"1.0.0", "1.0.0-rc.1", 1},
+		{"1.0.0-rc.1", "2.0.0", -1},
+		{"1.0.0", "0.1.0", 0},
+		{"1.0.0-rc", "1.0.0-dev", -1},
+		{"1.0.0-dev", "1.0.0-nightly", -1},
+		{"1.0.0-nightly", "1.0.0", -1},
+	}
+
+	for _, test := range tests {
+		c, err := CompareStage(test.v1, test.v2)
+		if err != nil {
+			t.Error(err)
+		}
+		if c != test.expected {
+			t.Errorf("expected %s and %s to be %d but got %d", test.v1, test.v2, test.expected, c)
+		}
+	}
+}
+
+func TestCompareOrString(t *testing.T) {
+	tests := []struct {
+		a        string
+		b        string
+		expected int
+	}{
+		{"1.10.0", "1.2.0", 1},
+		{"1.2.0", "latest", -1},
+		{"latest", "1.2.0", 1},
+		{"latest", "nightly", -1},
+		{"nightly", "nightly", 0},
+		{"v1.2.0", "1.2.0", 0},
+	}
+
+	for _, test := range tests {
+		if result := CompareOrString(test.a, test.b); result != test.expected {
+			t.Errorf("expected %s and %s to be %d but got %d", test.a, test.b, test.expected, result)
+		}
+	}
+
+	mixed := []string{"nightly", "1.10.0", "latest", "1.2.0"}
+	sort.SliceStable(mixed, func(i, j int) bool { return CompareOrString(mixed[i], mixed[j]) < 0 })
+	if result := strings.Join(mixed, " "); result != "1.2.0 1.10.0 latest nightly" {
+		t.Errorf("expected mixed list to sort as 1.2.0 1.10.0 latest nightly but got %s", result)
+	}
+}
+
+func TestCompareEpsilon(t *testing.T) {
+	tests := []struct {
+		v1       string
+		v2       string
+		epsilon  int
+		expected int
+	}{
+		{"1.2.3", "1.2.5", 2, 0},
+		{"1.2.5", "1.2.3", 2, 0},
+		{"1.2.3", "1.2.6", 2, -1},
+		{"1.2.9", "1.2.3", 2, 1},
+		{"1.2.3-rc.1", "1.2.3", 0, 0},
+		{"1.2.3", "1.2.4", 0, -1},
+		{"1.2.9", "1.3.0", 5, -1},
+	}
+
+	for _, test := range tests {
+		c, err := CompareEpsilon(test.v1, test.v2, test.epsilon)
+		if err != nil {
+			t.Error(err)
+		}
+		if c != test.expected {
+			t.Errorf("expected %s and %s to be %d within %d but got %d", test.v1, test.v2, test.expected, test.epsilon, c)
+		}
+	}
+
+	if _, err := CompareEpsilon("1.2.3", "1.2.4", -1); err == nil {
+		t.Error("expected an error for a negative epsilon")
+	}
+	if _, err := CompareEpsilon("1.2", "1.2.4", 1); err == nil {
+		t.Error("expected an error for an invalid version")
+	}
+}
+
+func TestCanonicalEqual(t *testing.T) {
+	tests := []struct {
+		v1        string
+		v2        string
+		expected  bool
+		precedent bool
+	}{
+		{"v1.2.3", "1.2.3", true, true},
+		{"01.2.3-rc.1+build", "v1.2.3-rc.1+build", true, true},
+		{"1.2.3+build.1", "1.2.3+build.2", false, true},
+		{"1.2.3+build", "1.2.3", false, true},
+		{"1.2.3-rc.1", "1.2.3", false, false},
+	}
+
+	for _, test := range tests {
+		same, err := CanonicalEqual(test.v1, test.v2)
+		if err != nil {
+			t.Error(err)
+		}
+		if same != test.expected {
+			t.Errorf("expected %s and %s to be canonically equal=%v but got %v", test.v1, test.v2, test.expected, same)
+		}
+		if c, _ := Compare(test.v1, test.v2); (c == 0) != test.precedent {
+			t.Errorf("expected %s and %s to have equal precedence=%v", test.v1, test.v2, test.precedent)
+		}
+	}
+
+	if _, err := CanonicalEqual("1.2.3", "latest"); err == nil {
+		t.Error("expected an error for an invalid version")
+	}
+}
+
+func TestCompareWithIgnorePrereleaseIdentifiers(t *testing.T) {
+	opts := CompareOptions{IgnorePrereleaseIdentifiers: []string{"ci"}}
+
+	tests := []struct {
+		v1       string
+		v2       string
+		opts     CompareOptions
+		expected int
+	}{
+		{"1.0.0-ci.5", "1.0.0", opts, 0},
+		{"1.0.0-ci.5", "1.0.0", CompareOptions{}, -1},
+		{"1.0.0-ci.12345", "1.0.0-ci.1", opts, 0},
+		{"1.0.0-ci.7.rc.1", "1.0.0-rc.1", opts, 0},
+		{"1.0.0-ci.7.rc.1", "1.0.0-rc.2", opts, -1},
+		{"1.0.0-rc.ci", "1.0.0-rc", opts, 1},
+		{"1.0.0-ci.5", "1.0.1", opts, -1},
+	}
+
+	for _, test := range tests {
+		c, err := CompareWith(test.v1, test.v2, test.opts)
+		if err != nil {
+			t.Error(err)
+		}
+		if c != test.expected {
+			t.Errorf("expected %s and %s to be %d with %+v but got %d", test.v1, test.v2, test.expected, test.opts, c)
+		}
+	}
+}
+
+func TestCompareWithReleaseEquivalentPrerelease(t *testing.T) {
+	opts := CompareOptions{ReleaseEquivalentPrerelease: []string{"final"}}
+
+	tests := []struct {
+		v1       string
+		v2       string
+		opts     CompareOptions
+		expected int
+	}{
+		{"1.2.3-final", "1.2.3", opts, 0},
+		{"1.2.3-final", "1.2.3", CompareOptions{}, -1},
+		{"1.2.3-final", "1.2.3-rc.9", opts, 1},
+		{"1.2.3-final.1", "1.2.3", opts, -1},
+		{"1.2.3-final", "1.2.4-rc.1", opts, -1},
+	}
+
+	for _, test := range tests {
+		c, err := CompareWith(test.v1, test.v2, test.opts)
+		if err != nil {
+			t.Error(err)
+		}
+		if c != test.expected {
+			t.Errorf("expected %s and %s to be %d with %+v but got %d", test.v1, test.v2, test.expected, test.opts, c)
+		}
+	}
+}
+
+func TestCompareWithNormalizer(t *testing.T) {
+	opts := CompareOptions{Normalizer: func(v string) string {
+		return strings.TrimPrefix(v, "release/")
+	}}
+
+	tests := []struct {
+		v1       string
+		v2       string
+		opts     CompareOptions
+		expected int
+	}{
+		{"release/1.2.3", "1.2.3", opts, 0},
+		{"release/1.2.3", "release/1.10.0", opts, -1},
+		{"release/v2.0.0", "1.9.9", opts, 1},
+	}
+
+	for _, test := range tests {
+		c, err := CompareWith(test.v1, test.v2, test.opts)
+		if err != nil {
+			t.Error(err)
+		}
+		if c != test.expected {
+			t.Errorf("expected %s and %s to be %d but got %d", test.v1, test.v2, test.expected, c)
+		}
+	}
+
+	// the normalizer runs before parsing, so it can turn unparseable input into a version
+	rename := CompareOptions{Normalizer: func(v string) string {
+		return strings.ReplaceAll(v, "_", ".")
+	}}
+	if c, err := CompareWith("1_2_3", "1.2.3", rename); err != nil || c != 0 {
+		t.Errorf("expected 1_2_3 to equal 1.2.3 after normalizing but got %d %v", c, err)
+	}
+	if _, err := CompareWith("1_2_3", "1.2.3", CompareOptions{}); err == nil {
+		t.Error("expected an error without a normalizer")
+	}
+}
+
+func TestCompareWithPrereleaseSynonyms(t *testing.T) {
+	opts := CompareOptions{PrereleaseSynonyms: map[string]string{"preview": "rc", "pre": "rc", "prerelease": "rc"}}
+
+	tests := []struct {
+		v1       string
+		v2       string
+		opts     CompareOptions
+		expected int
+	}{
+		{"1.0.0-preview.1", "1.0.0-rc.1", opts, 0},
+		{"1.0.0-preview.1", "1.0.0-rc.1", CompareOptions{}, -1},
+		{"1.0.0-pre.2", "1.0.0-prerelease.1", opts, 1},
+		{"1.0.0-preview", "1.0.0-beta.3", opts, 1},
+		{"1.0.0-alpha.preview", "1.0.0-alpha.rc", opts, -1},
+	}
+
+	for _, test := range tests {
+		c, err := CompareWith(test.v1, test.v2, test.opts)
+		if err != nil {
+			t.Error(err)
+		}
+		if c != test.expected {
+			t.Errorf("expected %s and %s to be %d with %+v but got %d", test.v1, test.v2, test.expected, test.opts, c)
+		}
+	}
+}
+
+func TestCompareWithBuildOutranksRelease(t *testing.T) {
+	opts := CompareOptions{BuildOutranksRelease: true}
+
+	tests := []struct {
+		v1       string
+		v2       string
+		opts     CompareOptions
+		expected int
+	}{
+		{"1.0.0+build", "1.0.0", opts, 1},
+		{"1.0.0", "1.0.0+build", opts, -1},
+		{"1.0.0+build", "1.0.0", CompareOptions{}, 0},
+		{"1.0.0+a", "1.0.0+b", opts, 0},
+		{"1.0.0+build", "1.0.1", opts, -1},
+		{"1.0.0-rc.1+build", "1.0.0", opts, -1},
+	}
+
+	for _, test := range tests {
+		c, err := CompareWith(test.v1, test.v2, test.opts)
+		if err != nil {
+			t.Error(err)
+		}
+		if c != test.expected {
+			t.Errorf("expected %s and %s to be %d with %+v but got %d", test.v1, test.v2, test.expected, test.opts, c)
+		}
+	}
+}
+
+func TestCompareWithIdentifierComparator(t *testing.T) {
+	// rank "beta" below every other identifier, otherwise use the standard rules
+	opts := CompareOptions{
+		IdentifierComparator: func(a, b string) int {
+			switch {
+			case a == b:
+				return 0
+			case a == "beta":
+				return -1
+			case b == "beta":
+				return 1
+			}
+			return compareIdentifier(a, b)
+		},
+	}
+
+	tests := []struct {
+		v1       string
+		v2       string
+		opts     CompareOptions
+		expected int
+	}{
+		{"1.0.0-beta", "1.0.0-alpha", opts, -1},
+		{"1.0.0-beta", "1.0.0-alpha", CompareOptions{}, 1},
+		{"1.0.0-rc.beta", "1.0.0-rc.1", opts, -1},
+		{"1.0.0-alpha.2", "1.0.0-alpha.10", opts, -1},
+		{"1.0.0-beta", "1.0.0", opts, -1},
+	}
+
+	for _, test := range tests {
+		c, err := CompareWith(test.v1, test.v2, test.opts)
+		if err != nil {
+			t.Error(err)
+		}
+		if c != test.expected {
+			t.Errorf("expected %s and %s to be %d but got %d", test.v1, test.v2, test.expected, c)
+		}
+	}
+}
+
+func TestCompareWarn(t *testing.T) {
+	tests := []struct {
+		v1       string
+		v2       string
+		expected int
+		warn     bool
+	}{
+		{"0.1.0", "0.2.0", -1, true},
+		{"0.3.1", "0.2.0", 1, true},
+		{"0.2.0", "0.2.5", -1, false},
+		{"1.1.0", "1.2.0", -1, false},
+		{"0.9.0", "1.0.0", -1, false},
+	}
+
+	for _, test := range tests {
+		c, warning, err := CompareWarn(test.v1, test.v2)
+		if err != nil {
+			t.Error(err)
+		}
+		if c != test.expected {
+			t.Errorf("expected %s and %s to be %d but got %d", test.v1, test.v2, test.expected, c)
+		}
+		if (warning != "") != test.warn {
+			t.Errorf("expected %s and %s to warn=%t but got %q", test.v1, test.v2, test.warn, warning)
+		}
+	}
+}
+
+func TestCompareWithCommitCountMeta(t *testing.T) {
+	opts := CompareOptions{CommitCountMeta: "r"}
+
+	tests := []struct {
+		v1       string
+		v2       string
+		opts     CompareOptions
+		expected int
+	}{
+		{"1.0.0+r42", "1.0.0+r100", opts, -1},
+		{"1.0.0+r42", "1.0.0+r100", CompareOptions{}, 0},
+		{"1.0.0+build.r7", "1.0.0+r7", opts, 0},
+		{"1.0.0+r42", "1.0.0+sha.abc", opts, 0},
+		{"1.0.1+r1", "1.0.0+r100", opts, 1},
+		{"1.0.0-rc.1+r200", "1.0.0+r1", opts, -1},
+	}
+
+	for _, test := range tests {
+		c, err := CompareWith(test.v1, test.v2, test.opts)
+		if err != nil {
+			t.Error(err)
+		}
+		if c != test.expected {
+			t.Errorf("expected %s and %s to be %d with %+v but got %d", test.v1, test.v2, test.expected, test.opts, c)
+		}
+	}
+}
+
+func TestCompareWithMetaComparator(t *testing.T) {
+	inverted := func(a, b string) int { return strings.Compare(b, a) }
+
+	tests := []struct {
+		v1       string
+		v2       string
+		opts     CompareOptions
+		expected int
+	}{
+		{"1.0.0+a", "1.0.0+b", CompareOptions{}, 0},
+		{"1.0.0+a", "1.0.0+b", CompareOptions{MetaTiebreak: true}, -1},
+		{"1.0.0+a", "1.0.0+b", CompareOptions{MetaTiebreak: true, MetaComparator: inverted}, 1},
+		{"1.0.0+a", "1.0.0+b", CompareOptions{MetaComparator: inverted}, 0},
+		{"1.0.0+a", "1.0.1+b", CompareOptions{MetaTiebreak: true, MetaComparator: inverted}, -1},
+		{"1.0.0+r2.a", "1.0.0+r10.b", CompareOptions{CommitCountMeta: "r", MetaTiebreak: true, MetaComparator: inverted}, -1},
+	}
+
+	for _, test := range tests {
+		c, err := CompareWith(test.v1, test.v2, test.opts)
+		if err != nil {
+			t.Error(err)
+		}
+		if c != test.expected {
+			t.Errorf("expected %s and %s to be %d but got %d", test.v1, test.v2, test.expected, c)
+		}
+	}
+}
+
+func TestCompareWithZeroIsLowest(t *testing.T) {
+	opts := CompareOptions{ZeroIsLowest: true}
+
+	tests := []struct {
+		v1       string
+		v2       string
+		opts     CompareOptions
+		expected int
+	}{
+		{"0.0.0", "0.0.0-alpha", CompareOptions{}, 1},
+		{"0.0.0", "0.0.0-alpha", opts, -1},
+		{"0.0.0-alpha", "0.0.0", opts, 1},
+		{"0.0.0+dev", "0.0.0-alpha", opts, -1},
+		{"0.0.0", "0.0.1-alpha", opts, -1},
+		{"0.0.0", "0.0.0+dev", opts, 0},
+		{"0.0.0-alpha", "0.0.0-beta", opts, -1},
+	}
+
+	for _, test := range tests {
+		c, err := CompareWith(test.v1, test.v2, test.opts)
+		if err != nil {
+			t.Error(err)
+		}
+		if c != test.expected {
+			t.Errorf("expected %s and %s to be %d with %+v but got %d", test.v1, test.v2, test.expected, test.opts, c)
+		}
+	}
+}
@@ -0,0 +1,1063 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Constraint is a parsed version constraint such as ">=1.2.0 <2.0.0" or "^1.4.2 || ^2.0.0".
+// A version satisfies the constraint when it satisfies every comparator in at least one
+// of its groups.
+type Constraint struct {
+	groups [][]comparator
+
+	// AllowPrereleaseMatches lets any prerelease version within the range satisfy the
+	// constraint. By default a prerelease only satisfies a constraint that names a
+	// prerelease of the same major, minor, and patch, so ^1.2.0 does not match
+	// 1.5.0-rc.1 while ^1.2.3-beta.1 matches 1.2.3-beta.2.
+	AllowPrereleaseMatches bool
+
+	// ExcludePrereleases keeps every prerelease version from satisfying the constraint,
+	// even one that names a prerelease of the same major, minor, and patch. It takes
+	// precedence over AllowPrereleaseMatches. See StableOnlyConstraint.
+	ExcludePrereleases bool
+}
+
+// comparator is a single operator and version pair, such as ">=1.2.0".
+type comparator struct {
+	op  string
+	ver Semver
+}
+
+// operators lists the supported operators, longest first so that ">=" is matched
+// before ">".
+var operators = []string{">=", "<=", ">", "<", "=", "^", "~"}
+
+// ParseConstraint takes a constraint string and parses it into a Constraint.
+//
+// A constraint is one or more groups separated by "||", of which at least one must hold.
+// A group is a list of terms separated by whitespace or commas, which must all hold. Each
+// term is a version optionally preceded by an operator:
+//
+//	1.2.3   or  =1.2.3   exactly 1.2.3
+//	>1.2.3  >=1.2.3      greater than (or equal to) 1.2.3
+//	<1.2.3  <=1.2.3      less than (or equal to) 1.2.3
+//	~1.2.3               patch-level changes: >=1.2.3 <1.3.0
+//	^1.2.3               changes that keep the left-most non-zero component:
+//	                     >=1.2.3 <2.0.0, ^0.2.3 is >=0.2.3 <0.3.0, and
+//	                     ^0.0.3 is >=0.0.3 <0.0.4
+//
+// A version may omit its minor and patch components, in which case the term covers every
+// version with the given prefix: "1.2" or "=1.2" is >=1.2.0 <1.3.0, ">1.2" is >=1.3.0,
+// "<=1.2" is <1.3.0, "~1" is >=1.0.0 <2.0.0, and "^0.2" is >=0.2.0 <0.3.0.
+//
+// Trailing components may also be written as the wildcards "x", "X", or "*", so "1.2.x"
+// and "1.2.*" mean the same as "1.2", and "*" alone matches every version. Wildcards
+// cannot be combined with ">" or "<".
+//
+// An operator may be separated from its version by whitespace, as in ">= 1.2.3".
+//
+// If the constraint or any group is empty, or any term cannot be parsed, the function
+// returns an empty Constraint and the error.
+//
+// Example:
+//
+//	c, err := ParseConstraint("^1.2.0 || >=2.0.0 <3.0.0")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	ver, _ := ParseVersion("2.4.2")
+//	fmt.Println(c.Check(ver)) // prints true
+func ParseConstraint(s string) (Constraint, error) {
+	if strings.TrimSpace(s) == "" {
+		return Constraint{}, fmt.Errorf("empty constraint")
+	}
+
+	var c Constraint
+	for _, text := range strings.Split(s, "||") {
+		group, err := parseGroup(text)
+		if err != nil {
+			return Constraint{}, err
+		}
+		c.groups = append(c.groups, group)
+	}
+
+	return c, nil
+}
+
+// parseGroup parses a list of terms separated by whitespace or commas into the
+// comparators that must all hold.
+func parseGroup(text string) ([]comparator, error) {
+	fields := strings.Fields(strings.ReplaceAll(text, ",", " "))
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("empty constraint group")
+	}
+
+	var group []comparator
+	for i := 0; i < len(fields); i++ {
+		term := fields[i]
+		if isOperator(term) && i+1 < len(fields) {
+			i++
+			term += fields[i]
+		}
+
+		comps, err := parseTerm(term)
+		if err != nil {
+			return nil, err
+		}
+		group = append(group, comps...)
+	}
+
+	return group, nil
+}
+
+// isOperator reports whether s consists of an operator alone.
+func isOperator(s string) bool {
+	for _, op := range operators {
+		if s == op {
+			return true
+		}
+	}
+	return false
+}
+
+// parseTerm parses a single constraint term into one or more comparators. Caret and
+// tilde terms, and terms with a partial version, expand into a lower and an upper bound.
+func parseTerm(term string) ([]comparator, error) {
+	op := "="
+	for _, o := range operators {
+		if strings.HasPrefix(term, o) {
+			op = o
+			break
+		}
+	}
+
+	ver, parts, err := parsePartial(strings.TrimPrefix(term, op))
+	if err != nil {
+		return nil, fmt.Errorf("invalid constraint term %q: %w", term, err)
+	}
+
+	if parts == 0 {
+		if op == ">" || op == "<" {
+			return nil, fmt.Errorf("invalid constraint term %q: %q cannot apply to a wildcard", term, op)
+		}
+		return []comparator{{">=", Semver{}}}, nil
+	}
+
+	switch op {
+	case "^":
+		upper := caretUpper(ver)
+		if parts < 3 && ver.Major == 0 && (parts == 1 || ver.Minor == 0) {
+			upper = nextPartial(ver, parts)
+		}
+		return []comparator{{">=", ver}, {"<", upper}}, nil
+	case "~":
+		if parts == 1 {
+			return []comparator{{">=", ver}, {"<", nextPartial(ver, parts)}}, nil
+		}
+		return []comparator{{">=", ver}, {"<", Semver{Major: ver.Major, Minor: ver.Minor + 1}}}, nil
+	}
+
+	if parts == 3 {
+		return []comparator{{op, ver}}, nil
+	}
+
+	switch op {
+	case ">":
+		return []comparator{{">=", nextPartial(ver, parts)}}, nil
+	case "<=":
+		return []comparator{{"<", nextPartial(ver, parts)}}, nil
+	case "=":
+		return []comparator{{">=", ver}, {"<", nextPartial(ver, parts)}}, nil
+	}
+
+	return []comparator{{op, ver}}, nil
+}
+
+// parsePartial parses a version that may omit its minor and patch components, such as
+// "1" or "1.2", and reports how many components were given. Missing components are zero,
+// and trailing wildcard components, as in "1.2.x" or "*", count as missing. A version
+// with all three components may carry a prerelease tag and metadata.
+func parsePartial(text string) (Semver, int, error) {
+	text = strings.TrimPrefix(text, "v")
+
+	// only the core may hold wildcards; "x" is a valid prerelease identifier
+	core := text
+	if i := strings.IndexAny(text, "-+"); i >= 0 {
+		core = text[:i]
+	}
+
+	split := strings.Split(core, ".")
+	for i, s := range split {
+		if !isWildcard(s) {
+			continue
+		}
+		if len(split) > 3 || core != text {
+			return Semver{}, 0, fmt.Errorf("invalid version %q", text)
+		}
+		for _, rest := range split[i+1:] {
+			if !isWildcard(rest) {
+				return Semver{}, 0, fmt.Errorf("invalid version %q: wildcard before %q", text, rest)
+			}
+		}
+		split = split[:i]
+		break
+	}
+	if len(split) == 0 {
+		return Semver{}, 0, nil
+	}
+	if len(split) >= 3 || core != text {
+		ver, err := ParseVersion(text)
+		if err != nil {
+			return Semver{}, 0, err
+		}
+		if normalize(text) != text {
+			return Semver{}, 0, fmt.Errorf("invalid version %q", text)
+		}
+		return ver, 3, nil
+	}
+
+	vers := make([]int, 3)
+	for i, s := range split {
+		if s == "" {
+			return Semver{}, 0, fmt.Errorf("%w in %q", ErrEmptyComponent, text)
+		}
+		if !isNumeric(s) {
+			return Semver{}, 0, fmt.Errorf("invalid version %q", text)
+		}
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return Semver{}, 0, err
+		}
+		vers[i] = n
+	}
+
+	return Semver{Major: vers[0], Minor: vers[1], Patch: vers[2]}, len(split), nil
+}
+
+// isWildcard reports whether s is a wildcard version component.
+func isWildcard(s string) bool {
+	return s == "x" || s == "X" || s == "*"
+}
+
+// nextPartial returns the lowest version above every version matching a partial
+// version with the given number of components: 1.x becomes 2.0.0 and 1.2.x becomes
+// 1.3.0.
+func nextPartial(v Semver, parts int) Semver {
+	if parts == 1 {
+		return Semver{Major: v.Major + 1}
+	}
+	return Semver{Major: v.Major, Minor: v.Minor + 1}
+}
+
+// caretUpper returns the exclusive upper bound of the caret range starting at v.
+func caretUpper(v Semver) Semver {
+	switch {
+	case v.Major > 0:
+		return Semver{Major: v.Major + 1}
+	case v.Minor > 0:
+		return Semver{Minor: v.Minor + 1}
+	}
+	return Semver{Patch: v.Patch + 1}
+}
+
+// StableOnlyConstraint returns the constraint ">=BASE <NEXT-MAJOR" with
+// ExcludePrereleases set, so that only stable releases from base up to the next major
+// version satisfy it. This is the common "only ship stable" gate.
+//
+// If base cannot be parsed, the function returns an empty Constraint and the error.
+//
+// Example:
+//
+//	c, err := StableOnlyConstraint("1.2.3")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(c.Check(Semver{Major: 1, Minor: 3, Prerelease: "rc.1"})) // prints false
+func StableOnlyConstraint(base string) (Constraint, error) {
+	ver, err := parse(base)
+	if err != nil {
+		return Constraint{}, err
+	}
+
+	c := Constraint{
+		groups: [][]comparator{{
+			{">=", ver.key()},
+			{"<", Semver{Major: ver.Major + 1}},
+		}},
+		ExcludePrereleases: true,
+	}
+	return c, nil
+}
+
+// Check reports whether v satisfies every comparator in one of the constraint's groups.
+// Prerelease versions are excluded unless the constraint opts into them; see
+// AllowPrereleaseMatches.
+func (c Constraint) Check(v Semver) bool {
+	ok, _ := c.CheckDetailed(v)
+	return ok
+}
+
+// CheckDetailed reports whether v satisfies the constraint like Check and, when it does
+// not, describes the first bound it violated, such as "below lower bound >=1.2.0" or "at
+// or above exclusive upper bound <2.0.0". For a constraint with several groups, the
+// reasons of every group are joined with " or ". The reason is empty when v satisfies
+// the constraint.
+//
+// Example:
+//
+//	c, _ := ParseConstraint("^1.2.0")
+//	ok, reason := c.CheckDetailed(Semver{Major: 2})
+//	fmt.Println(ok, reason) // prints false at or above exclusive upper bound <2.0.0
+func (c Constraint) CheckDetailed(v Semver) (bool, string) {
+	groups := c.groups
+	if len(groups) == 0 {
+		groups = [][]comparator{nil}
+	}
+
+	reasons := make([]string, 0, len(groups))
+	for _, group := range groups {
+		ok, reason := c.checkGroup(group, v)
+		if ok {
+			return true, ""
+		}
+		reasons = append(reasons, reason)
+	}
+	return false, strings.Join(reasons, " or ")
+}
+
+// checkGroup reports whether v satisfies every comparator in group and, when it does not,
+// describes why.
+func (c Constraint) checkGroup(group []comparator, v Semver) (bool, string) {
+	for _, comp := range group {
+		if !comp.check(v) {
+			return false, comp.violation()
+		}
+	}
+
+	if v.Prerelease == "" {
+		return true, ""
+	}
+	if c.ExcludePrereleases {
+		return false, fmt.Sprintf("prerelease %s is excluded", v)
+	}
+	if c.AllowPrereleaseMatches {
+		return true, ""
+	}
+	for _, comp := range group {
+		if comp.ver.Prerelease != "" && comp.ver.core() == v.core() {
+			return true, ""
+		}
+	}
+	return false, fmt.Sprintf("prerelease %s is excluded", v)
+}
+
+// violation describes how a version failing the comparator falls outside of it.
+func (comp comparator) violation() string {
+	switch comp.op {
+	case ">":
+		return fmt.Sprintf("at or below exclusive lower bound >%s", comp.ver)
+	case ">=":
+		return fmt.Sprintf("below lower bound >=%s", comp.ver)
+	case "<":
+		return fmt.Sprintf("at or above exclusive upper bound <%s", comp.ver)
+	case "<=":
+		return fmt.Sprintf("above upper bound <=%s", comp.ver)
+	}
+	return fmt.Sprintf("not equal to %s", comp.ver)
+}
+
+// check reports whether v satisfies a single comparator.
+func (comp comparator) check(v Semver) bool {
+	result := compare(v, comp.ver)
+	switch comp.op {
+	case ">":
+		return result > 0
+	case ">=":
+		return result >= 0
+	case "<":
+		return result < 0
+	case "<=":
+		return result <= 0
+	}
+	return result == 0
+}
+
+// Satisfies reports whether a version string satisfies a constraint string such as
+// "^1.2.3", "~1.2.3", "1.2.3", or ">=1.2.0 <2.0.0". See ParseConstraint for the supported
+// syntax.
+//
+// A caret range allows changes that keep the left-most non-zero component, so "^0.2.3"
+// means ">=0.2.3 <0.3.0". Prerelease versions only satisfy the constraint when one of its
+// comparators names a prerelease of the same major, minor, and patch.
+//
+// If either the version or the constraint cannot be parsed, the function returns false
+// and the error.
+//
+// Example:
+//
+//	ok, err := Satisfies("1.4.0", "^1.2.3")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(ok) // prints true
+func Satisfies(version, constraint string) (bool, error) {
+	c, err := ParseConstraint(constraint)
+	if err != nil {
+		return false, err
+	}
+	ver, err := parse(version)
+	if err != nil {
+		return false, err
+	}
+	return c.Check(ver), nil
+}
+
+// NextUnder returns the smallest version strictly greater than current that satisfies
+// constraint, assuming every patch release exists, and reports whether there is one. It
+// proposes the smallest in-range upgrade: the next patch when current sits inside the
+// range, the lower bound when current is below it, and none when current is at or past
+// the upper edge.
+//
+// Candidates are the release of a prerelease current, the next patch, minor, and major
+// release of current, and the versions the constraint's bounds name, together with the
+// next patch release of each. Prereleases are only proposed when the constraint accepts
+// them, as with Check.
+//
+// If either the version or the constraint cannot be parsed, the function returns an empty
+// Semver structure, false, and the error.
+//
+// Example:
+//
+//	next, ok, err := NextUnder("1.2.3", "^1.2.0")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(next, ok) // prints 1.2.4 true
+func NextUnder(current string, constraint string) (Semver, bool, error) {
+	c, err := ParseConstraint(constraint)
+	if err != nil {
+		return Semver{}, false, err
+	}
+	cur, err := parse(current)
+	if err != nil {
+		return Semver{}, false, err
+	}
+
+	candidates := []Semver{cur.core()}
+	for _, level := range []string{"patch", "minor", "major"} {
+		if next, err := cur.Next(level); err == nil {
+			candidates = append(candidates, next)
+		}
+	}
+	for _, group := range c.groups {
+		for _, comp := range group {
+			candidates = append(candidates, comp.ver.key(), comp.ver.core())
+			if next, err := comp.ver.Next("patch"); err == nil {
+				candidates = append(candidates, next)
+			}
+		}
+	}
+
+	var best Semver
+	found := false
+	for _, ver := range candidates {
+		if compare(ver, cur) <= 0 || !c.Check(ver) {
+			continue
+		}
+		if !found || compare(ver, best) < 0 {
+			best, found = ver, true
+		}
+	}
+	return best, found, nil
+}
+
+// FilterSatisfying returns the versions that satisfy a constraint, in their original
+// order.
+//
+// If the constraint or any version cannot be parsed, the function returns nil and the
+// error.
+//
+// Example:
+//
+//	matches, err := FilterSatisfying([]string{"1.3.0", "2.0.0", "1.2.0"}, "^1.2.0")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(matches) // prints [1.3.0 1.2.0]
+func FilterSatisfying(versions []string, constraint string) ([]string, error) {
+	c, err := ParseConstraint(constraint)
+	if err != nil {
+		return nil, err
+	}
+	vers, err := parseAll(versions)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := []string{}
+	for i, ver := range vers {
+		if c.Check(ver) {
+			matches = append(matches, versions[i])
+		}
+	}
+
+	return matches, nil
+}
+
+// AllSatisfying returns the versions that satisfy a constraint, sorted in ascending
+// order of precedence.
+//
+// If the constraint or any version cannot be parsed, the function returns nil and the
+// error.
+//
+// Example:
+//
+//	matches, err := AllSatisfying([]string{"1.3.0", "2.0.0", "1.2.0"}, "^1.2.0")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(matches) // prints [1.2.0 1.3.0]
+func AllSatisfying(versions []string, constraint string) ([]string, error) {
+	matches, err := FilterSatisfying(versions, constraint)
+	if err != nil {
+		return nil, err
+	}
+
+	vers, _ := parseAll(matches)
+	sort.Stable(byVersion{matches, vers})
+	return matches, nil
+}
+
+// ErrNoSatisfying is returned by MaxSatisfying and MinSatisfying when no version
+// satisfies the constraint.
+var ErrNoSatisfying = errors.New("no version satisfies the constraint")
+
+// MaxSatisfying returns the highest version that satisfies a constraint, which is the
+// "latest compatible release" a dependency resolver picks. Prereleases are only
+// considered as the constraint allows; to include or exclude them, parse the constraint,
+// set AllowPrereleaseMatches or ExcludePrereleases, and use Constraint.MaxSatisfying.
+//
+// If the constraint or any version cannot be parsed, or no version satisfies the
+// constraint, the function returns an empty Semver structure and the error. The latter
+// wraps ErrNoSatisfying.
+//
+// Example:
+//
+//	ver, err := MaxSatisfying([]string{"1.2.0", "1.9.3", "2.0.0"}, "^1.2.0")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(ver) // prints 1.9.3
+func MaxSatisfying(versions []string, constraint string) (Semver, error) {
+	return satisfying(versions, constraint, 1)
+}
+
+// MinSatisfying returns the lowest version that satisfies a constraint, as
+// MaxSatisfying does for the highest.
+//
+// If the constraint or any version cannot be parsed, or no version satisfies the
+// constraint, the function returns an empty Semver structure and the error. The latter
+// wraps ErrNoSatisfying.
+//
+// Example:
+//
+//	ver, err := MinSatisfying([]string{"1.2.0", "1.9.3", "2.0.0"}, ">1.2.0")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(ver) // prints 1.9.3
+func MinSatisfying(versions []string, constraint string) (Semver, error) {
+	return satisfying(versions, constraint, -1)
+}
+
+// satisfying parses versions and constraint and returns the extreme satisfying version
+// in the direction of sign.
+func satisfying(versions []string, constraint string, sign int) (Semver, error) {
+	c, err := ParseConstraint(constraint)
+	if err != nil {
+		return Semver{}, err
+	}
+	vers, err := parseAll(versions)
+	if err != nil {
+		return Semver{}, err
+	}
+
+	ver, ok := c.extremeSatisfying(vers, sign)
+	if !ok {
+		return Semver{}, fmt.Errorf("%w %q", ErrNoSatisfying, constraint)
+	}
+	return ver, nil
+}
+
+// MaxSatisfying returns the highest of vers that satisfies c and true, or an empty
+// Semver structure and false when none does. Among versions of equal precedence the
+// first one wins.
+//
+// Example:
+//
+//	c, _ := ParseConstraint("^1.2.0")
+//	c.AllowPrereleaseMatches = true
+//	ver, _ := c.MaxSatisfying([]Semver{{Major: 1, Minor: 4}, {Major: 1, Minor: 5, Prerelease: "rc.1"}})
+//	fmt.Println(ver) // prints 1.5.0-rc.1
+func (c Constraint) MaxSatisfying(vers []Semver) (Semver, bool) {
+	return c.extremeSatisfying(vers, 1)
+}
+
+// MinSatisfying returns the lowest of vers that satisfies c and true, or an empty Semver
+// structure and false when none does. Among versions of equal precedence the first one
+// wins.
+func (c Constraint) MinSatisfying(vers []Semver) (Semver, bool) {
+	return c.extremeSatisfying(vers, -1)
+}
+
+// extremeSatisfying returns the version in vers that satisfies c and ranks first in the
+// direction of sign.
+func (c Constraint) extremeSatisfying(vers []Semver, sign int) (Semver, bool) {
+	var best Semver
+	found := false
+	for _, ver := range vers {
+		if !c.Check(ver) {
+			continue
+		}
+		if !found || compare(ver, best) == sign {
+			best, found = ver, true
+		}
+	}
+	return best, found
+}
+
+// ConstraintDiffOver describes how changing a constraint from oldConstraint to
+// newConstraint changes its coverage of a pool of candidate versions. Added holds the
+// pool versions that match only the new constraint and removed those that match only the
+// old one, both in their original order.
+//
+// If either constraint or any pool version cannot be parsed, the function returns nil
+// slices and the error.
+//
+// Example:
+//
+//	pool := []string{"1.2.0", "1.3.0", "2.0.0"}
+//	added, removed, err := ConstraintDiffOver("~1.2.0", "^1.2.0", pool)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(added, removed) // prints [1.3.0] []
+func ConstraintDiffOver(oldConstraint, newConstraint string, pool []string) (added, removed []string, err error) {
+	before, err := ParseConstraint(oldConstraint)
+	if err != nil {
+		return nil, nil, err
+	}
+	after, err := ParseConstraint(newConstraint)
+	if err != nil {
+		return nil, nil, err
+	}
+	vers, err := parseAll(pool)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	added, removed = []string{}, []string{}
+	for i, ver := range vers {
+		was, is := before.Check(ver), after.Check(ver)
+		switch {
+		case is && !was:
+			added = append(added, pool[i])
+		case was && !is:
+			removed = append(removed, pool[i])
+		}
+	}
+
+	return added, removed, nil
+}
+
+// CoverageSet returns a minimal useful set of versions from pool to cache for a
+// constraint, such as for an offline mirror: the lowest and highest versions satisfying
+// the constraint, plus the latest stable release in the whole pool. The result holds the
+// original strings without duplicates, sorted in ascending order of precedence. When no
+// version satisfies the constraint, only the latest stable release is kept.
+//
+// If the constraint or any pool version cannot be parsed, the function returns nil and
+// the error.
+//
+// Example:
+//
+//	pool := []string{"1.1.0", "1.2.0", "1.4.0", "1.9.0", "2.1.0"}
+//	set, err := CoverageSet(pool, "^1.2.0")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(set) // prints [1.2.0 1.9.0 2.1.0]
+func CoverageSet(pool []string, constraint string) ([]string, error) {
+	c, err := ParseConstraint(constraint)
+	if err != nil {
+		return nil, err
+	}
+	vers, err := parseAll(pool)
+	if err != nil {
+		return nil, err
+	}
+
+	low, high, latest := -1, -1, -1
+	for i, ver := range vers {
+		if c.Check(ver) {
+			if low < 0 || compare(ver, vers[low]) < 0 {
+				low = i
+			}
+			if high < 0 || compare(ver, vers[high]) > 0 {
+				high = i
+			}
+		}
+		if ver.Prerelease == "" && (latest < 0 || compare(ver, vers[latest]) > 0) {
+			latest = i
+		}
+	}
+
+	set := byVersion{raw: []string{}}
+	seen := make(map[int]bool)
+	for _, i := range []int{low, high, latest} {
+		if i < 0 || seen[i] {
+			continue
+		}
+		seen[i] = true
+		set.raw = append(set.raw, pool[i])
+		set.vers = append(set.vers, vers[i])
+	}
+
+	sort.Sort(set)
+	return set.raw, nil
+}
+
+// CoveragePercent returns the fraction of pool versions satisfying a constraint, from
+// 0.0 to 1.0, as a measure of how permissive the constraint is. Every pool entry counts,
+// including duplicates.
+//
+// If the pool is empty, or the constraint or any pool version cannot be parsed, the
+// function returns 0 and an error.
+//
+// Example:
+//
+//	share, err := CoveragePercent([]string{"1.2.0", "1.4.0", "2.0.0", "2.1.0"}, "^1.2.0")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(share) // prints 0.5
+func CoveragePercent(pool []string, constraint string) (float64, error) {
+	if len(pool) == 0 {
+		return 0, fmt.Errorf("empty pool")
+	}
+	c, err := ParseConstraint(constraint)
+	if err != nil {
+		return 0, err
+	}
+	vers, err := parseAll(pool)
+	if err != nil {
+		return 0, err
+	}
+
+	matched := 0
+	for _, ver := range vers {
+		if c.Check(ver) {
+			matched++
+		}
+	}
+	return float64(matched) / float64(len(vers)), nil
+}
+
+// interval is the range of versions allowed by a set of comparators. A nil bound means
+// the range is unbounded on that side.
+type interval struct {
+	lower *comparator
+	upper *comparator
+}
+
+// intervalOf intersects comps into a single interval and reports whether any version
+// can fall inside it.
+func intervalOf(comps []comparator) (interval, bool) {
+	var iv interval
+	for i := range comps {
+		comp := comps[i]
+		if comp.op == ">" || comp.op == ">=" || comp.op == "=" {
+			lower := comparator{">=", comp.ver}
+			if comp.op == ">" {
+				lower.op = ">"
+			}
+			if iv.lower == nil || tighterLower(lower, *iv.lower) {
+				iv.lower = &lower
+			}
+		}
+		if comp.op == "<" || comp.op == "<=" || comp.op == "=" {
+			upper := comparator{"<=", comp.ver}
+			if comp.op == "<" {
+				upper.op = "<"
+			}
+			if iv.upper == nil || tighterUpper(upper, *iv.upper) {
+				iv.upper = &upper
+			}
+		}
+	}
+
+	if iv.lower == nil || iv.upper == nil {
+		return iv, true
+	}
+	result := compare(iv.lower.ver, iv.upper.ver)
+	if result > 0 || (result == 0 && (iv.lower.op == ">" || iv.upper.op == "<")) {
+		return iv, false
+	}
+	return iv, true
+}
+
+// tighterLower reports whether lower bound a excludes more versions than b.
+func tighterLower(a, b comparator) bool {
+	result := compare(a.ver, b.ver)
+	return result > 0 || (result == 0 && a.op == ">")
+}
+
+// tighterUpper reports whether upper bound a excludes more versions than b.
+func tighterUpper(a, b comparator) bool {
+	result := compare(a.ver, b.ver)
+	return result < 0 || (result == 0 && a.op == "<")
+}
+
+// LowestSupportedMajor returns the smallest major version that can satisfy every one of
+// the given constraints at the same time.
+//
+// The constraints are intersected into a single range, and the major version of its
+// lower bound is returned. A range without a lower bound starts at major version 0. For
+// constraints with several groups, every combination of one group per constraint is
+// tried and the smallest major version is returned.
+//
+// If any constraint cannot be parsed, or the constraints have no version in common, the
+// function returns 0 and an error.
+//
+// Example:
+//
+//	major, err := LowestSupportedMajor([]string{">=1.4.0", "<3.0.0", "^2.1.0"})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(major) // prints 2
+func LowestSupportedMajor(constraints []string) (int, error) {
+	cs := make([]Constraint, len(constraints))
+	for i, s := range constraints {
+		c, err := ParseConstraint(s)
+		if err != nil {
+			return 0, err
+		}
+		cs[i] = c
+	}
+
+	major, ok := lowestMajor(cs, nil)
+	if !ok {
+		return 0, fmt.Errorf("constraints %q have no version in common", constraints)
+	}
+	return major, nil
+}
+
+// lowestMajor intersects comps with one group of each constraint in cs and returns the
+// smallest major version of a lower bound among the intersections that are not empty.
+func lowestMajor(cs []Constraint, comps []comparator) (int, bool) {
+	if len(cs) == 0 {
+		iv, ok := intervalOf(comps)
+		if !ok || iv.lower == nil {
+			return 0, ok
+		}
+		return iv.lower.ver.Major, true
+	}
+
+	best, found := 0, false
+	for _, group := range cs[0].groups {
+		joined := append(comps[:len(comps):len(comps)], group...)
+		if major, ok := lowestMajor(cs[1:], joined); ok && (!found || major < best) {
+			best, found = major, true
+		}
+	}
+	return best, found
+}
+
+// ConstraintForSet returns the tightest constraint covering a set of versions and reports
+// whether the constraint matches exactly those versions.
+//
+// The constraint is ">=LOW <=HIGH" for the lowest and highest versions in the set, or the
+// version itself for a set of one. The set is exactly representable only when the range
+// matches no release outside of it, which means the versions are consecutive patches of a
+// single MAJOR.MINOR line. A set such as 1.2.2 and 1.3.0 is not, because the range also
+// matches 1.2.3. Sets containing prereleases are never exactly representable, because
+// constraints exclude prereleases by default. Duplicates and metadata are ignored.
+//
+// If the set is empty or any version cannot be parsed, the function returns an empty
+// string, false, and an error.
+//
+// Example:
+//
+//	c, exact, err := ConstraintForSet([]string{"1.2.1", "1.2.0", "1.2.2"})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(c, exact) // prints >=1.2.0 <=1.2.2 true
+func ConstraintForSet(versions []string) (string, bool, error) {
+	if len(versions) == 0 {
+		return "", false, fmt.Errorf("no versions given")
+	}
+	vers, err := parseAll(versions)
+	if err != nil {
+		return "", false, err
+	}
+
+	sorted := uniqueSorted(vers)
+	low, high := sorted[0], sorted[len(sorted)-1]
+
+	exact := true
+	for i, ver := range sorted {
+		if ver.Prerelease != "" || (i > 0 && !isSuccessor(sorted[i-1], ver)) {
+			exact = false
+		}
+	}
+
+	if len(sorted) == 1 {
+		return low.String(), exact, nil
+	}
+	return fmt.Sprintf(">=%s <=%s", low, high), exact, nil
+}
+
+// TightestCaret returns the caret constraint "^LOW" for the lowest version in a set,
+// which is the tightest caret range whose bounds include every version of the set. This
+// infers a manifest constraint from the versions observed in use.
+//
+// The set must fit a single caret range: one major line from 1.0.0 on, one minor line
+// below it, and one patch below 0.1.0. Metadata is dropped from the constraint. The range
+// covers prereleases by precedence, but as with any constraint Check only matches those
+// on the core of the lower bound unless AllowPrereleaseMatches is set.
+//
+// If the set is empty, any version cannot be parsed, or the versions span incompatible
+// lines, the function returns an empty string and an error.
+//
+// Example:
+//
+//	c, err := TightestCaret([]string{"1.4.0", "1.2.3", "1.9.1"})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(c) // prints ^1.2.3
+func TightestCaret(versions []string) (string, error) {
+	if len(versions) == 0 {
+		return "", fmt.Errorf("no versions given")
+	}
+	vers, err := parseAll(versions)
+	if err != nil {
+		return "", err
+	}
+
+	lowest := extreme(vers, -1)
+	low := vers[lowest]
+	for i, ver := range vers {
+		if !compatible(low, ver) {
+			return "", fmt.Errorf("%s is not caret compatible with %s", versions[i], versions[lowest])
+		}
+	}
+	return "^" + low.key().String(), nil
+}
+
+// ApproxConstraint returns a single range constraint covering a nearly contiguous set of
+// releases, tolerating up to maxGaps missing patch versions inside the range. A negative
+// maxGaps means no limit.
+//
+// The constraint has the same form as in ConstraintForSet. After sorting, the missing
+// patches between neighbouring releases are counted: 1.2.1 to 1.2.4 misses two. A move
+// to the next minor or major line, as from 1.2.4 to 1.3.0, leaves every patch above 1.2.4
+// inside the range, so the number of missing patches is unbounded and only a negative
+// maxGaps accepts it. Skipping a whole minor or major line always fails. Prereleases,
+// duplicates, and metadata are ignored.
+//
+// If the set holds no releases, any version cannot be parsed, or more than maxGaps
+// patches are missing, the function returns an empty string and an error.
+//
+// Example:
+//
+//	c, err := ApproxConstraint([]string{"1.2.0", "1.2.1", "1.2.3"}, 1)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(c) // prints >=1.2.0 <=1.2.3
+func ApproxConstraint(versions []string, maxGaps int) (string, error) {
+	vers, err := parseAll(versions)
+	if err != nil {
+		return "", err
+	}
+
+	releases := []Semver{}
+	for _, ver := range vers {
+		if ver.Prerelease == "" {
+			releases = append(releases, ver)
+		}
+	}
+	if len(releases) == 0 {
+		return "", fmt.Errorf("no releases given")
+	}
+
+	sorted := uniqueSorted(releases)
+	gaps := 0
+	for i := 1; i < len(sorted); i++ {
+		prev, next := sorted[i-1], sorted[i]
+		switch {
+		case prev.Major == next.Major && prev.Minor == next.Minor:
+			gaps += next.Patch - prev.Patch - 1
+		case isNextLine(prev, next):
+			if maxGaps >= 0 {
+				return "", fmt.Errorf("%s and %s are on different lines, leaving unbounded patches in the range", prev, next)
+			}
+		default:
+			return "", fmt.Errorf("%s and %s are a whole minor line or more apart", prev, next)
+		}
+		if maxGaps >= 0 && gaps > maxGaps {
+			return "", fmt.Errorf("%d missing patches exceed the tolerance of %d", gaps, maxGaps)
+		}
+	}
+
+	low, high := sorted[0], sorted[len(sorted)-1]
+	if len(sorted) == 1 {
+		return low.String(), nil
+	}
+	return fmt.Sprintf(">=%s <=%s", low, high), nil
+}
+
+// uniqueSorted returns the versions of vers without metadata, deduplicated and sorted in
+// ascending order of precedence.
+func uniqueSorted(vers []Semver) []Semver {
+	seen := make(map[Semver]bool, len(vers))
+	unique := make([]Semver, 0, len(vers))
+	for _, ver := range vers {
+		if k := ver.key(); !seen[k] {
+			seen[k] = true
+			unique = append(unique, k)
+		}
+	}
+
+	sort.Slice(unique, func(i, j int) bool {
+		return compare(unique[i], unique[j]) < 0
+	})
+	return unique
+}
+
+// isSuccessor reports whether next immediately follows prev among releases, which is
+// only the case for the next patch of prev. The first release of the next minor or major
+// line is not a successor, because any number of patches of prev may come between them.
+func isSuccessor(prev, next Semver) bool {
+	succ, err := prev.Next("patch")
+	return err == nil && succ == next
+}
+
+// isNextLine reports whether next lies on the minor line that immediately follows the
+// one of prev: the next minor line, or the first minor line of the next major.
+func isNextLine(prev, next Semver) bool {
+	line := Semver{Major: next.Major, Minor: next.Minor}
+	for _, level := range []string{"minor", "major"} {
+		if succ, _ := prev.Next(level); succ == line {
+			return true
+		}
+	}
+	return false
+}
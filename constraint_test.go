@@ -0,0 +1,641 @@
+package semver
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestConstraintCheck(t *testing.T) {
+	tests := []struct {
+		constraint string
+		v          string
+		expected   bool
+	}{
+		{"1.2.3", "1.2.3", true},
+		{"=1.2.3", "1.2.4", false},
+		{">1.2.3", "1.2.4", true},
+		{">1.2.3", "1.2.3", false},
+		{">=1.2.3", "1.2.3", true},
+		{"<1.2.3", "1.2.2", true},
+		{"<=1.2.3", "1.2.4", false},
+		{">= 1.2.0 < 2.0.0", "1.9.9", true},
+		{">=1.2.0 <2.0.0", "2.0.0", false},
+		{"^1.2.3", "1.9.0", true},
+		{"^1.2.3", "1.2.2", false},
+		{"^1.2.3", "2.0.0", false},
+		{"^0.2.3", "0.2.9", true},
+		{"^0.2.3", "0.3.0", false},
+		{"^0.0.3", "0.0.3", true},
+		{"^0.0.3", "0.0.4", false},
+		{"~1.2.3", "1.2.9", true},
+		{"~1.2.3", "1.3.0", false},
+		{"^v1.2.3", "1.5.0", true},
+		{">=1.20", "1.20.0", true},
+		{">=1.20", "1.19.9", false},
+		{">1.2", "1.2.9", false},
+		{">1.2", "1.3.0", true},
+		{"<1.22", "1.21.9", true},
+		{"<1.22", "1.22.0", false},
+		{"<=1.2", "1.2.9", true},
+		{"<=1.2", "1.3.0", false},
+		{"1.2", "1.2.7", true},
+		{"=1.2", "1.3.0", false},
+		{"~1.4", "1.4.2", true},
+		{"~1.4", "1.5.0", false},
+		{"~1", "1.9.0", true},
+		{"~1", "2.0.0", false},
+		{"^1.2", "1.9.0", true},
+		{"^0.2", "0.3.0", false},
+		{"^0", "0.9.0", true},
+		{"^0", "1.0.0", false},
+		{"^0.0", "0.0.9", true},
+		{"^0.0", "0.1.0", false},
+		{"^1.2.0 || >=2.0.0 <3.0.0", "1.4.2", true},
+		{"^1.2.0 || >=2.0.0 <3.0.0", "2.9.0", true},
+		{"^1.2.0 || >=2.0.0 <3.0.0", "3.0.0", false},
+		{"^1.2.0 || >=2.0.0 <3.0.0", "1.1.0", false},
+		{">=1.2.0, <1.5.0", "1.4.9", true},
+		{">=1.2.0, <1.5.0", "1.5.0", false},
+		{"~1.4||~1.6", "1.6.3", true},
+		{"~1.4||~1.6", "1.5.0", false},
+	}
+
+	for _, test := range tests {
+		c, err := ParseConstraint(test.constraint)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ver, err := ParseVersion(test.v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := c.Check(ver); got != test.expected {
+			t.Errorf("expected %s to satisfy %q=%t but got %t", test.v, test.constraint, test.expected, got)
+		}
+	}
+
+	for _, bad := range []string{"", "^", ">=1.2.", "~abc", "^1.2.3junk", "1.2.3.4", "^1.2.0 ||", "|| ^1.2.0", "^1.2.0 || , || ^2.0.0"} {
+		if _, err := ParseConstraint(bad); err == nil {
+			t.Errorf("expected an error for constraint %q", bad)
+		}
+	}
+}
+
+func TestAllSatisfying(t *testing.T) {
+	versions := []string{"1.4.0", "2.0.0", "1.2.0", "0.9.0", "1.10.1", "1.2.5"}
+
+	matches, err := AllSatisfying(versions, "^1.2.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"1.2.0", "1.2.5", "1.4.0", "1.10.1"}; !reflect.DeepEqual(matches, expected) {
+		t.Errorf("expected %v but got %v", expected, matches)
+	}
+
+	matches, err = FilterSatisfying(versions, "^1.2.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"1.4.0", "1.2.0", "1.10.1", "1.2.5"}; !reflect.DeepEqual(matches, expected) {
+		t.Errorf("expected %v but got %v", expected, matches)
+	}
+
+	if _, err := AllSatisfying(versions, ">>1.0.0"); err == nil {
+		t.Error("expected an error for an invalid constraint")
+	}
+}
+
+func TestLowestSupportedMajor(t *testing.T) {
+	tests := []struct {
+		constraints []string
+		expected    int
+	}{
+		{[]string{">=1.4.0", "<3.0.0", "^2.1.0"}, 2},
+		{[]string{">=1.4.0", "<3.0.0"}, 1},
+		{[]string{"<2.0.0"}, 0},
+		{[]string{"^1.2.0", "~1.5.0"}, 1},
+		{[]string{">=1.2.3", "<=1.2.3"}, 1},
+		{[]string{"^1.2.0 || ^2.0.0", ">=2.1.0"}, 2},
+		{[]string{"^3.0.0 || ^1.2.0", "<4.0.0"}, 1},
+	}
+
+	for _, test := range tests {
+		major, err := LowestSupportedMajor(test.constraints)
+		if err != nil {
+			t.Error(err)
+		}
+		if major != test.expected {
+			t.Errorf("expected lowest major of %v to be %d but got %d", test.constraints, test.expected, major)
+		}
+	}
+
+	contradictory := [][]string{
+		{"^1.2.0", "^2.0.0"},
+		{">1.2.3", "<=1.2.3"},
+		{">=2.0.0", "<2.0.0"},
+		{"1.2.3", "1.2.4"},
+		{"^1.2.0 || ^2.0.0", ">=3.0.0"},
+	}
+	for _, constraints := range contradictory {
+		if _, err := LowestSupportedMajor(constraints); err == nil {
+			t.Errorf("expected an error for contradictory constraints %v", constraints)
+		}
+	}
+}
+
+func TestConstraintPrerelease(t *testing.T) {
+	tests := []struct {
+		constraint string
+		v          string
+		allow      bool
+		expected   bool
+	}{
+		{"^1.2.0", "2.0.0-alpha.1", false, false},
+		{"^1.2.3-beta.1", "1.2.3-beta.2", false, true},
+		{"^1.2.3-beta.1", "1.2.3-alpha.1", false, false},
+		{"^1.2.3-beta.1", "1.2.4-beta.1", false, false},
+		{"^1.2.3-beta.1", "1.2.4", false, true},
+		{">=1.2.3-rc.1 <1.2.3", "1.2.3-rc.2", false, true},
+		{"1.2.3-rc.1", "1.2.3-rc.1", false, true},
+		{"^1.2.3-beta.1", "1.2.3-beta.2", true, true},
+		{"^1.2.0", "1.5.0-rc.1", true, true},
+		{"^1.2.0", "1.5.0-rc.1", false, false},
+		{"^1.2.0", "1.2.0-rc.1", true, false},
+	}
+
+	for _, test := range tests {
+		c, err := ParseConstraint(test.constraint)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.AllowPrereleaseMatches = test.allow
+		ver, err := ParseVersion(test.v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := c.Check(ver); got != test.expected {
+			t.Errorf("expected %s to satisfy %q (allow=%t)=%t but got %t", test.v, test.constraint, test.allow, test.expected, got)
+		}
+	}
+}
+
+func TestNextUnder(t *testing.T) {
+	tests := []struct {
+		current    string
+		constraint string
+		expected   string
+		ok         bool
+	}{
+		{"1.2.3", "^1.2.0", "1.2.4", true},
+		{"1.9.9", "~1.9.0", "1.9.10", true},
+		{"1.2.3", "<=1.2.3", "", false},
+		{"1.2.9", ">=1.2.0 <1.3.0", "1.2.10", true},
+		{"1.2.3", ">=1.2.0 <=1.2.3", "", false},
+		{"1.0.0", ">=2.0.0 <3.0.0", "2.0.0", true},
+		{"1.2.3", ">1.5.0", "1.5.1", true},
+		{"1.2.3-rc.1", "^1.2.0", "1.2.3", true},
+		{"1.2.3-beta.1", "^1.2.3-beta.1", "1.2.3", true},
+		{"2.0.0", "^1.2.0", "", false},
+	}
+
+	for _, test := range tests {
+		next, ok, err := NextUnder(test.current, test.constraint)
+		if err != nil {
+			t.Error(err)
+		}
+		if ok != test.ok || (ok && next.String() != test.expected) {
+			t.Errorf("expected the next version after %s under %q to be %q (%t) but got %s (%t)", test.current, test.constraint, test.expected, test.ok, next, ok)
+		}
+	}
+
+	if _, _, err := NextUnder("1.2", "^1.2.0"); err == nil {
+		t.Error("expected an error for an invalid version")
+	}
+	if _, _, err := NextUnder("1.2.3", "^y"); err == nil {
+		t.Error("expected an error for an invalid constraint")
+	}
+}
+
+func TestStableOnlyConstraint(t *testing.T) {
+	tests := []struct {
+		base     string
+		v        string
+		expected bool
+	}{
+		{"1.2.3", "1.2.3", true},
+		{"1.2.3", "1.9.0", true},
+		{"1.2.3", "1.3.0-rc.1", false},
+		{"1.2.3", "1.2.2", false},
+		{"1.2.3", "2.0.0", false},
+		{"1.2.3-rc.1", "1.2.3-rc.2", false},
+		{"1.2.3-rc.1", "1.2.3", true},
+		{"0.2.0", "0.5.1", true},
+	}
+
+	for _, test := range tests {
+		c, err := StableOnlyConstraint(test.base)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ver, err := ParseVersion(test.v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := c.Check(ver); got != test.expected {
+			t.Errorf("expected %s to satisfy the stable constraint for %s=%t but got %t", test.v, test.base, test.expected, got)
+		}
+
+		c.AllowPrereleaseMatches = true
+		if ver.Prerelease != "" && c.Check(ver) {
+			t.Errorf("expected ExcludePrereleases to override AllowPrereleaseMatches for %s", test.v)
+		}
+	}
+
+	if _, err := StableOnlyConstraint("1.2"); err == nil {
+		t.Error("expected an error for an invalid base")
+	}
+}
+
+func TestConstraintForSet(t *testing.T) {
+	tests := []struct {
+		versions   []string
+		constraint string
+		exact      bool
+	}{
+		{[]string{"1.2.1", "1.2.0", "1.2.2", "1.2.3"}, ">=1.2.0 <=1.2.3", true},
+		{[]string{"1.2.0", "1.2.1", "1.3.0", "2.0.0"}, ">=1.2.0 <=2.0.0", false},
+		{[]string{"1.2.2", "1.3.0"}, ">=1.2.2 <=1.3.0", false},
+		{[]string{"1.9.0", "2.0.0"}, ">=1.9.0 <=2.0.0", false},
+		{[]string{"1.2.0", "1.2.2"}, ">=1.2.0 <=1.2.2", false},
+		{[]string{"1.2.0", "1.3.1"}, ">=1.2.0 <=1.3.1", false},
+		{[]string{"1.2.1", "1.2.1-rc.1", "1.2.2"}, ">=1.2.1-rc.1 <=1.2.2", false},
+		{[]string{"1.2.0", "v1.2.0+build"}, "1.2.0", true},
+	}
+
+	for _, test := range tests {
+		c, exact, err := ConstraintForSet(test.versions)
+		if err != nil {
+			t.Error(err)
+		}
+		if c != test.constraint || exact != test.exact {
+			t.Errorf("expected %v to give %q (exact=%t) but got %q (exact=%t)", test.versions, test.constraint, test.exact, c, exact)
+		}
+	}
+
+	if _, _, err := ConstraintForSet(nil); err == nil {
+		t.Error("expected an error for an empty set")
+	}
+}
+
+func TestSatisfies(t *testing.T) {
+	tests := []struct {
+		v          string
+		constraint string
+		expected   bool
+	}{
+		{"1.4.0", "^1.2.3", true},
+		{"2.0.0", "^1.2.3", false},
+		{"1.2.2", "^1.2.3", false},
+		{"0.2.9", "^0.2.3", true},
+		{"0.3.0", "^0.2.3", false},
+		{"1.2.9", "~1.2.3", true},
+		{"1.3.0", "~1.2.3", false},
+		{"1.2.3", "1.2.3", true},
+		{"1.2.4", "1.2.3", false},
+		{"1.2.4", ">1.2.3", true},
+		{"1.2.3", ">1.2.3", false},
+		{"1.2.3", ">=1.2.3", true},
+		{"1.2.2", "<1.2.3", true},
+		{"1.2.3", "<=1.2.3", true},
+		{"v1.5.0", ">=1.2.0 <2.0.0", true},
+		{"1.3.0-beta.1", "^1.2.3", false},
+		{"1.2.3-beta.2", "^1.2.3-beta.1", true},
+	}
+
+	for _, test := range tests {
+		result, err := Satisfies(test.v, test.constraint)
+		if err != nil {
+			t.Error(err)
+		}
+		if result != test.expected {
+			t.Errorf("expected %s to satisfy %q=%v but got %v", test.v, test.constraint, test.expected, result)
+		}
+	}
+
+	if _, err := Satisfies("1.2.3", "^^1"); err == nil {
+		t.Error("expected an error for an invalid constraint")
+	}
+	if _, err := Satisfies("latest", "^1.2.3"); err == nil {
+		t.Error("expected an error for an invalid version")
+	}
+}
+
+func TestCheckDetailed(t *testing.T) {
+	tests := []struct {
+		constraint string
+		v          string
+		ok         bool
+		reason     string
+	}{
+		{"^1.2.0", "1.1.9", false, "below lower bound >=1.2.0"},
+		{"^1.2.0", "2.0.0", false, "at or above exclusive upper bound <2.0.0"},
+		{">1.2.0 <=1.5.0", "1.2.0", false, "at or below exclusive lower bound >1.2.0"},
+		{">1.2.0 <=1.5.0", "1.5.1", false, "above upper bound <=1.5.0"},
+		{"1.2.3", "1.2.4", false, "not equal to 1.2.3"},
+		{"^1.2.0", "1.5.0-rc.1", false, "prerelease 1.5.0-rc.1 is excluded"},
+		{"^1.2.0", "1.5.0", true, ""},
+		{"^1.2.0 || ^3.0.0", "2.0.0", false, "at or above exclusive upper bound <2.0.0 or below lower bound >=3.0.0"},
+		{"^1.2.0 || ^3.0.0", "3.1.0", true, ""},
+	}
+
+	for _, test := range tests {
+		c, err := ParseConstraint(test.constraint)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ver, err := ParseVersion(test.v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ok, reason := c.CheckDetailed(ver)
+		if ok != test.ok || reason != test.reason {
+			t.Errorf("expected %s against %q to be %v %q but got %v %q", test.v, test.constraint, test.ok, test.reason, ok, reason)
+		}
+	}
+}
+
+func TestConstraintDiffOver(t *testing.T) {
+	pool := []string{"1.1.0", "1.2.0", "1.2.5", "1.3.0", "1.9.0", "2.0.0"}
+
+	tests := []struct {
+		old     string
+		new     string
+		added   []string
+		removed []string
+	}{
+		{"~1.2.0", "^1.2.0", []string{"1.3.0", "1.9.0"}, []string{}},
+		{"^1.2.0", "~1.2.0", []string{}, []string{"1.3.0", "1.9.0"}},
+		{"^1.2.0", ">=1.3.0 <=2.0.0", []string{"2.0.0"}, []string{"1.2.0", "1.2.5"}},
+		{"^1.2.0", "^1.2.0", []string{}, []string{}},
+	}
+
+	for _, test := range tests {
+		added, removed, err := ConstraintDiffOver(test.old, test.new, pool)
+		if err != nil {
+			t.Error(err)
+		}
+		if !reflect.DeepEqual(added, test.added) || !reflect.DeepEqual(removed, test.removed) {
+			t.Errorf("expected %q to %q to add %v and remove %v but got %v and %v", test.old, test.new, test.added, test.removed, added, removed)
+		}
+	}
+
+	if _, _, err := ConstraintDiffOver("^1.2.0", "^^1", pool); err == nil {
+		t.Error("expected an error for an invalid constraint")
+	}
+}
+
+func TestCoveragePercent(t *testing.T) {
+	pool := []string{"1.2.0", "1.4.0", "2.0.0", "2.1.0"}
+
+	tests := []struct {
+		constraint string
+		expected   float64
+	}{
+		{"^1.2.0", 0.5},
+		{">=1.0.0", 1},
+		{"^3.0.0", 0},
+		{"~1.4.0", 0.25},
+	}
+
+	for _, test := range tests {
+		share, err := CoveragePercent(pool, test.constraint)
+		if err != nil {
+			t.Error(err)
+		}
+		if share != test.expected {
+			t.Errorf("expected %q to cover %v of the pool but got %v", test.constraint, test.expected, share)
+		}
+	}
+
+	if _, err := CoveragePercent(nil, "^1.2.0"); err == nil {
+		t.Error("expected an error for an empty pool")
+	}
+	if _, err := CoveragePercent(pool, "^y"); err == nil {
+		t.Error("expected an error for an invalid constraint")
+	}
+}
+
+func TestTightestCaret(t *testing.T) {
+	tests := []struct {
+		versions []string
+		expected string
+	}{
+		{[]string{"1.4.0", "1.2.3", "1.9.1"}, "^1.2.3"},
+		{[]string{"1.2.3+build.5"}, "^1.2.3"},
+		{[]string{"0.2.5", "0.2.1"}, "^0.2.1"},
+		{[]string{"1.3.0", "1.3.0-rc.1"}, "^1.3.0-rc.1"},
+	}
+
+	for _, test := range tests {
+		c, err := TightestCaret(test.versions)
+		if err != nil {
+			t.Error(err)
+		}
+		if c != test.expected {
+			t.Errorf("expected %v to give %s but got %s", test.versions, test.expected, c)
+		}
+
+		constraint, err := ParseConstraint(c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		constraint.AllowPrereleaseMatches = true
+		for _, v := range test.versions {
+			ver, err := parse(v)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !constraint.Check(ver) {
+				t.Errorf("expected %s to satisfy %s", v, c)
+			}
+		}
+	}
+
+	for _, bad := range [][]string{{"1.2.0", "2.0.0"}, {"0.2.0", "0.3.0"}, {"0.0.1", "0.0.2"}, {}, {"1.2.0", "nope"}} {
+		if _, err := TightestCaret(bad); err == nil {
+			t.Errorf("expected an error for %v", bad)
+		}
+	}
+}
+
+func TestApproxConstraint(t *testing.T) {
+	tests := []struct {
+		versions []string
+		maxGaps  int
+		expected string
+	}{
+		{[]string{"1.2.0", "1.2.1", "1.2.3"}, 1, ">=1.2.0 <=1.2.3"},
+		{[]string{"1.2.0", "1.2.1", "1.2.2"}, 0, ">=1.2.0 <=1.2.2"},
+		{[]string{"1.2.4", "1.3.1", "1.2.3"}, -1, ">=1.2.3 <=1.3.1"},
+		{[]string{"1.9.0", "2.0.0", "2.0.0-rc.1"}, -1, ">=1.9.0 <=2.0.0"},
+		{[]string{"1.2.0", "1.2.9"}, -1, ">=1.2.0 <=1.2.9"},
+		{[]string{"1.2.0"}, 0, "1.2.0"},
+	}
+
+	for _, test := range tests {
+		c, err := ApproxConstraint(test.versions, test.maxGaps)
+		if err != nil {
+			t.Error(err)
+		}
+		if c != test.expected {
+			t.Errorf("expected %v with %d gaps to give %q but got %q", test.versions, test.maxGaps, test.expected, c)
+		}
+	}
+
+	failures := []struct {
+		versions []string
+		maxGaps  int
+	}{
+		{[]string{"1.2.0", "1.2.3"}, 1},
+		{[]string{"1.2.0", "1.2.2", "1.2.4"}, 1},
+		{[]string{"1.2.0", "1.4.0"}, 5},
+		{[]string{"1.2.0", "1.4.0"}, -1},
+		{[]string{"1.2.4", "1.3.0"}, 0},
+		{[]string{"1.2.4", "1.3.1"}, 100},
+		{[]string{"1.9.0", "2.0.0"}, 0},
+		{[]string{"1.0.0-rc.1"}, 0},
+	}
+
+	for _, test := range failures {
+		if _, err := ApproxConstraint(test.versions, test.maxGaps); err == nil {
+			t.Errorf("expected an error for %v with %d gaps", test.versions, test.maxGaps)
+		}
+	}
+}
+
+func TestCoverageSet(t *testing.T) {
+	pool := []string{"1.1.0", "1.2.0", "1.2.5", "1.4.0", "1.9.0", "2.0.0", "2.1.0", "3.0.0-rc.1"}
+
+	tests := []struct {
+		constraint string
+		expected   []string
+	}{
+		{"^1.2.0", []string{"1.2.0", "1.9.0", "2.1.0"}},
+		{"~1.2.0", []string{"1.2.0", "1.2.5", "2.1.0"}},
+		{"^2.0.0", []string{"2.0.0", "2.1.0"}},
+		{"1.4.0", []string{"1.4.0", "2.1.0"}},
+		{"^4.0.0", []string{"2.1.0"}},
+	}
+
+	for _, test := range tests {
+		set, err := CoverageSet(pool, test.constraint)
+		if err != nil {
+			t.Error(err)
+		}
+		if !reflect.DeepEqual(set, test.expected) {
+			t.Errorf("expected coverage of %q to be %v but got %v", test.constraint, test.expected, set)
+		}
+	}
+
+	if _, err := CoverageSet(pool, ">>1"); err == nil {
+		t.Error("expected an error for an invalid constraint")
+	}
+}
+
+func TestWildcardConstraint(t *testing.T) {
+	tests := []struct {
+		v          string
+		constraint string
+		expected   bool
+	}{
+		{"1.2.9", "1.2.x", true},
+		{"1.3.0", "1.2.x", false},
+		{"1.2.9", "1.2.*", true},
+		{"1.9.0", "1.x", true},
+		{"1.9.0", "1.x.x", true},
+		{"2.0.0", "1.X", false},
+		{"0.0.1", "*", true},
+		{"42.0.0", "x", true},
+		{"1.0.0-rc.1", "*", false},
+		{"1.3.0", ">=1.2.x", true},
+		{"1.3.0", "<=1.2.x", false},
+		{"1.9.9", "^1.x", true},
+		{"1.2.5", "~1.2.x", true},
+		{"3.0.0", "1.x || 3.x", true},
+		{"1.0.0-rc.y", ">=1.0.0-rc.x", true},
+		{"1.0.0-rc.a", ">=1.0.0-rc.x", false},
+		{"1.0.0", ">=1.0.0-rc.x+build.X", true},
+	}
+
+	for _, test := range tests {
+		result, err := Satisfies(test.v, test.constraint)
+		if err != nil {
+			t.Error(err)
+		}
+		if result != test.expected {
+			t.Errorf("expected %s to satisfy %q=%v but got %v", test.v, test.constraint, test.expected, result)
+		}
+	}
+
+	for _, constraint := range []string{"1.x.3", ">*", "<1.x.x.x", "1.y", "1.x-rc.1", "1.2-rc.x"} {
+		if _, err := ParseConstraint(constraint); err == nil {
+			t.Errorf("expected an error for %q", constraint)
+		}
+	}
+}
+
+func TestMaxMinSatisfying(t *testing.T) {
+	versions := []string{"1.4.0", "2.0.0", "v1.2.0", "0.9.0", "1.10.1", "1.11.0-rc.1", "1.2.5"}
+	tests := []struct {
+		constraint string
+		hi, lo     string
+	}{
+		{"^1.2.0", "1.10.1", "1.2.0"},
+		{"~1.2.0", "1.2.5", "1.2.0"},
+		{"*", "2.0.0", "0.9.0"},
+		{"<1.0.0 || >=2.0.0", "2.0.0", "0.9.0"},
+		{"^1.11.0-rc.1", "1.11.0-rc.1", "1.11.0-rc.1"},
+	}
+
+	for _, test := range tests {
+		hi, err := MaxSatisfying(versions, test.constraint)
+		if err != nil {
+			t.Error(err)
+		}
+		if hi.String() != test.hi {
+			t.Errorf("expected the highest version satisfying %q to be %s but got %s", test.constraint, test.hi, hi)
+		}
+		lo, err := MinSatisfying(versions, test.constraint)
+		if err != nil {
+			t.Error(err)
+		}
+		if lo.String() != test.lo {
+			t.Errorf("expected the lowest version satisfying %q to be %s but got %s", test.constraint, test.lo, lo)
+		}
+	}
+
+	if _, err := MaxSatisfying(versions, "^3.0.0"); !errors.Is(err, ErrNoSatisfying) {
+		t.Errorf("expected %v but got %v", ErrNoSatisfying, err)
+	}
+	if _, err := MinSatisfying(nil, "^1.0.0"); !errors.Is(err, ErrNoSatisfying) {
+		t.Errorf("expected %v but got %v", ErrNoSatisfying, err)
+	}
+	if _, err := MaxSatisfying(versions, ">>1.0.0"); err == nil {
+		t.Error("expected an error for an invalid constraint")
+	}
+	if _, err := MinSatisfying([]string{"latest"}, "^1.0.0"); err == nil {
+		t.Error("expected an error for an invalid version")
+	}
+
+	vers, _ := parseAll(versions)
+	c, _ := ParseConstraint("^1.2.0")
+	c.AllowPrereleaseMatches = true
+	if ver, ok := c.MaxSatisfying(vers); !ok || ver.String() != "1.11.0-rc.1" {
+		t.Errorf("expected prereleases to be included but got %s", ver)
+	}
+	c, _ = StableOnlyConstraint("1.11.0-rc.1")
+	if ver, ok := c.MinSatisfying(vers); ok {
+		t.Errorf("expected no stable version to satisfy the constraint but got %s", ver)
+	}
+}
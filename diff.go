@@ -0,0 +1,102 @@
+package semver
+
+import "fmt"
+
+// Change describes the most significant component that differs between two versions.
+// The constants are ordered from least to most significant, so changes can be compared
+// with < and >.
+type Change int
+
+const (
+	// NoChange means the versions are identical.
+	NoChange Change = iota
+	// MetaOnly means the versions differ only in metadata.
+	MetaOnly
+	// PrereleaseChange means the versions differ in their prerelease tag but share the
+	// major, minor, and patch components.
+	PrereleaseChange
+	// PatchChange means the versions differ in the patch component.
+	PatchChange
+	// MinorChange means the versions differ in the minor component.
+	MinorChange
+	// MajorChange means the versions differ in the major component.
+	MajorChange
+)
+
+// String returns the name of the change: "none", "metadata", "prerelease", "patch",
+// "minor", or "major".
+func (c Change) String() string {
+	switch c {
+	case NoChange:
+		return "none"
+	case MetaOnly:
+		return "metadata"
+	case PrereleaseChange:
+		return "prerelease"
+	case PatchChange:
+		return "patch"
+	case MinorChange:
+		return "minor"
+	case MajorChange:
+		return "major"
+	}
+	return fmt.Sprintf("Change(%d)", int(c))
+}
+
+// Diff takes two version strings, normalizes and parses them into Semver structures, and
+// reports the most significant component that differs between them. The order of the
+// versions does not matter, so a downgrade from 2.0.0 to 1.9.0 is a MajorChange just like
+// the upgrade. An upgrade bot can use the result to label a pull request as a major,
+// minor, or patch update.
+//
+// If there is an error parsing either version string, the function returns NoChange and
+// the error.
+//
+// Example:
+//
+//	change, err := Diff("1.2.3", "1.3.0")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(change) // prints minor
+func Diff(v1, v2 string) (Change, error) {
+	ver1, err := parse(v1)
+	if err != nil {
+		return NoChange, err
+	}
+	ver2, err := parse(v2)
+	if err != nil {
+		return NoChange, err
+	}
+	return diff(ver1, ver2), nil
+}
+
+// diff returns the most significant component that differs between a and b.
+func diff(a, b Semver) Change {
+	switch {
+	case a.Major != b.Major:
+		return MajorChange
+	case a.Minor != b.Minor:
+		return MinorChange
+	case a.Patch != b.Patch:
+		return PatchChange
+	case a.Prerelease != b.Prerelease:
+		return PrereleaseChange
+	case a.Meta != b.Meta:
+		return MetaOnly
+	}
+	return NoChange
+}
+
+// parseLevel converts a level name ("major", "minor", or "patch") into a Change.
+func parseLevel(level string) (Change, error) {
+	switch level {
+	case "major":
+		return MajorChange, nil
+	case "minor":
+		return MinorChange, nil
+	case "patch":
+		return PatchChange, nil
+	}
+	return NoChange, fmt.Errorf("invalid level %q", level)
+}
@@ -0,0 +1,54 @@
+package semver
+
+import "testing"
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		v1, v2   string
+		expected Change
+	}{
+		{"1.2.3", "2.0.0", MajorChange},
+		{"2.0.0", "1.9.0", MajorChange},
+		{"1.2.3", "1.3.0", MinorChange},
+		{"1.2.3", "v1.2.4", PatchChange},
+		{"1.2.3-rc.1", "1.2.3", PrereleaseChange},
+		{"1.2.3-rc.1", "1.2.3-rc.2", PrereleaseChange},
+		{"1.2.3+build.1", "1.2.3+build.2", MetaOnly},
+		{"1.2.3", "1.2.3", NoChange},
+	}
+
+	for _, test := range tests {
+		result, err := Diff(test.v1, test.v2)
+		if err != nil {
+			t.Error(err)
+		}
+		if result != test.expected {
+			t.Errorf("expected the change from %s to %s to be %v but got %v", test.v1, test.v2, test.expected, result)
+		}
+	}
+
+	if _, err := Diff("latest", "1.2.3"); err == nil {
+		t.Error("expected an error for an invalid version")
+	}
+	if _, err := Diff("1.2.3", "latest"); err == nil {
+		t.Error("expected an error for an invalid version")
+	}
+}
+
+func TestChangeString(t *testing.T) {
+	tests := map[Change]string{
+		NoChange:         "none",
+		MetaOnly:         "metadata",
+		PrereleaseChange: "prerelease",
+		PatchChange:      "patch",
+		MinorChange:      "minor",
+		MajorChange:      "major",
+		Change(42):       "Change(42)",
+	}
+
+	for change, expected := range tests {
+		if result := change.String(); result != expected {
+			t.Errorf("expected %d to print as %s but got %s", int(change), expected, result)
+		}
+	}
+}
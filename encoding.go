@@ -0,0 +1,426 @@
+package semver
+
+import (
+	"bufio"
+	"database/sql/driver"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ParseJSONArray takes a JSON array of version strings and parses every element into a
+// Semver structure.
+//
+// Each element is normalized and parsed the same way Compare does. If the data is not a
+// JSON array of strings, or if any element fails to parse, the function returns nil and
+// an error. Element errors identify the index of the first invalid element.
+//
+// Example:
+//
+//	vers, err := ParseJSONArray([]byte(`["1.0.0", "1.1.0-rc.1"]`))
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(len(vers)) // prints 2
+func ParseJSONArray(data []byte) ([]Semver, error) {
+	var raw []string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	vers := make([]Semver, 0, len(raw))
+	for i, v := range raw {
+		ver, err := parse(v)
+		if err != nil {
+			return nil, fmt.Errorf("element %d (%q): %w", i, v, err)
+		}
+		vers = append(vers, ver)
+	}
+
+	return vers, nil
+}
+
+// ParseTSV reads tab-separated name and version pairs, one per line, and returns the
+// parsed versions keyed by name.
+//
+// Blank lines are skipped. Each remaining line must hold exactly two tab-separated
+// fields; surrounding whitespace is trimmed from both. When a name appears more than
+// once, the last line wins.
+//
+// If a line is malformed or its version cannot be parsed, the function returns nil and
+// an error identifying the line number.
+//
+// Example:
+//
+//	deps, err := ParseTSV(strings.NewReader("api\t1.2.3\nweb\t2.0.0\n"))
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(deps["web"].Major) // prints 2
+func ParseTSV(r io.Reader) (map[string]Semver, error) {
+	result := make(map[string]Semver)
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+
+		fields := strings.Split(text, "\t")
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("line %d: expected 2 tab-separated fields but got %d", line, len(fields))
+		}
+
+		name := strings.TrimSpace(fields[0])
+		ver, err := parse(strings.TrimSpace(fields[1]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		result[name] = ver
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// StreamMax reads version strings from r, one per line, and returns the one with the
+// highest precedence without retaining the whole list in memory.
+//
+// Blank lines are skipped and surrounding whitespace is trimmed from each line; the
+// trimmed line of the winner is returned. When several versions share the highest
+// precedence, the first of them is returned.
+//
+// If r holds no versions or a line cannot be parsed, the function returns an empty string
+// and an error identifying the line number.
+//
+// Example:
+//
+//	latest, err := StreamMax(strings.NewReader("1.2.0\nv1.10.0\n1.9.3\n"))
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(latest) // prints v1.10.0
+func StreamMax(r io.Reader) (string, error) {
+	return streamExtreme(r, 1)
+}
+
+// StreamMin reads version strings from r, one per line, and returns the one with the
+// lowest precedence. It follows the same rules as StreamMax.
+//
+// Example:
+//
+//	oldest, err := StreamMin(strings.NewReader("1.2.0\nv1.10.0\n1.9.3\n"))
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(oldest) // prints 1.2.0
+func StreamMin(r io.Reader) (string, error) {
+	return streamExtreme(r, -1)
+}
+
+// streamExtreme returns the line of r whose version compares as sign against every
+// other line: 1 picks the highest and -1 the lowest.
+func streamExtreme(r io.Reader, sign int) (string, error) {
+	var (
+		best    string
+		bestVer Semver
+		found   bool
+	)
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		ver, err := parse(text)
+		if err != nil {
+			return "", fmt.Errorf("line %d: %w", line, err)
+		}
+		if !found || compare(ver, bestVer) == sign {
+			best, bestVer, found = text, ver, true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+
+	if !found {
+		return "", fmt.Errorf("no versions given")
+	}
+	return best, nil
+}
+
+// MaxInCSVColumn reads CSV records from r and returns the version with the highest
+// precedence found in the given zero-based column. Every record is treated as data, so a
+// header row must be skipped by the caller. Surrounding whitespace is trimmed from each
+// field and the trimmed field of the winner is returned.
+//
+// If r holds no records, a record is malformed or too short, or a field cannot be parsed,
+// the function returns an empty string and an error identifying the row number.
+//
+// Example:
+//
+//	latest, err := MaxInCSVColumn(strings.NewReader("api,1.2.0\nweb,1.10.0\n"), 1)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(latest) // prints 1.10.0
+func MaxInCSVColumn(r io.Reader, column int) (string, error) {
+	var (
+		best    string
+		bestVer Semver
+		found   bool
+	)
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("row %d: %w", row, err)
+		}
+		if column < 0 || column >= len(record) {
+			return "", fmt.Errorf("row %d: no column %d in %d fields", row, column, len(record))
+		}
+
+		text := strings.TrimSpace(record[column])
+		ver, err := parse(text)
+		if err != nil {
+			return "", fmt.Errorf("row %d: %w", row, err)
+		}
+		if !found || compare(ver, bestVer) > 0 {
+			best, bestVer, found = text, ver, true
+		}
+	}
+
+	if !found {
+		return "", fmt.Errorf("no versions given")
+	}
+	return best, nil
+}
+
+// MarshalSortedJSON encodes a list of version strings as a JSON array of canonical
+// versions sorted in ascending order of precedence, so the output does not depend on the
+// order or spelling of the input.
+//
+// If any version cannot be parsed, the function returns nil and the error.
+//
+// Example:
+//
+//	data, err := MarshalSortedJSON([]string{"v1.10.0", "1.2.0"})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(string(data)) // prints ["1.2.0","1.10.0"]
+func MarshalSortedJSON(versions []string) ([]byte, error) {
+	vers, err := parseAll(versions)
+	if err != nil {
+		return nil, err
+	}
+
+	canonical := make([]string, len(vers))
+	for i, ver := range vers {
+		canonical[i] = ver.String()
+	}
+	sort.Stable(byVersion{canonical, vers})
+
+	return json.Marshal(canonical)
+}
+
+// kvWidth is the number of digits KVKey pads each of the major, minor, and patch
+// components to, enough for any non-negative int64.
+const kvWidth = 19
+
+// KVKey encodes s as a key for key-value stores that order keys lexically, such as etcd
+// or Consul, so that range scans return versions in order of precedence. FromKVKey
+// decodes the key back into s.
+//
+// The major, minor, and patch components are zero-padded to 19 digits. A "~" follows for
+// a release and a "-" for a prerelease, so prereleases sort before their release. Each
+// prerelease identifier is encoded as "0", a three-digit length, and the digits for a
+// numeric identifier, or as "1" and the identifier itself otherwise, joined by ",". This
+// orders numeric identifiers numerically and below alphanumeric ones, and a shorter list
+// of identifiers below a longer one that it prefixes. Metadata is appended after a "+",
+// which sorts below every other character used, so versions of equal precedence stay
+// adjacent.
+//
+// Numeric identifiers with more than 999 digits are not supported, and leading zeros in
+// numeric identifiers, which the specification forbids, are dropped.
+//
+// Example:
+//
+//	ver, _ := ParseVersion("1.2.3-rc.1")
+//	fmt.Println(ver.KVKey()) // prints 0000000000000000001.0000000000000000002.0000000000000000003-1rc,00011
+func (s Semver) KVKey() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%0*d.%0*d.%0*d", kvWidth, s.Major, kvWidth, s.Minor, kvWidth, s.Patch)
+
+	if s.Prerelease == "" {
+		b.WriteByte('~')
+	} else {
+		b.WriteByte('-')
+		for i, id := range strings.Split(s.Prerelease, ".") {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			if isNumeric(id) {
+				digits := strings.TrimLeft(id, "0")
+				if digits == "" {
+					digits = "0"
+				}
+				fmt.Fprintf(&b, "0%03d%s", len(digits), digits)
+			} else {
+				b.WriteString("1" + id)
+			}
+		}
+	}
+
+	if s.Meta != "" {
+		b.WriteString("+" + s.Meta)
+	}
+	return b.String()
+}
+
+// FromKVKey decodes a key produced by KVKey back into a Semver structure.
+//
+// If the key is not a valid KVKey encoding, the function returns an empty Semver
+// structure and an error.
+//
+// Example:
+//
+//	ver, err := FromKVKey("0000000000000000001.0000000000000000002.0000000000000000003~")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(ver) // prints 1.2.3
+func FromKVKey(k string) (Semver, error) {
+	coreLen := 3*kvWidth + 2
+	if len(k) <= coreLen {
+		return Semver{}, fmt.Errorf("invalid KV key %q: too short", k)
+	}
+
+	var ver Semver
+	parts := strings.Split(k[:coreLen], ".")
+	for i, field := range []*int{&ver.Major, &ver.Minor, &ver.Patch} {
+		if len(parts) != 3 || len(parts[i]) != kvWidth || !isNumeric(parts[i]) {
+			return Semver{}, fmt.Errorf("invalid KV key %q: malformed core", k)
+		}
+		n, err := strconv.Atoi(parts[i])
+		if err != nil {
+			return Semver{}, fmt.Errorf("invalid KV key %q: %w", k, err)
+		}
+		*field = n
+	}
+
+	marker := k[coreLen]
+	rest, meta, hasMeta := strings.Cut(k[coreLen+1:], "+")
+	if hasMeta && meta == "" {
+		return Semver{}, fmt.Errorf("invalid KV key %q: empty metadata", k)
+	}
+	ver.Meta = meta
+
+	switch marker {
+	case '~':
+		if rest != "" {
+			return Semver{}, fmt.Errorf("invalid KV key %q: unexpected %q after release", k, rest)
+		}
+		return ver, nil
+	case '-':
+		if rest == "" {
+			return Semver{}, fmt.Errorf("invalid KV key %q: empty prerelease", k)
+		}
+	default:
+		return Semver{}, fmt.Errorf("invalid KV key %q: unknown marker %q", k, marker)
+	}
+
+	ids := strings.Split(rest, ",")
+	for i, id := range ids {
+		switch {
+		case strings.HasPrefix(id, "0") && len(id) > 4 && isNumeric(id[1:]):
+			n, _ := strconv.Atoi(id[1:4])
+			if len(id[4:]) != n {
+				return Semver{}, fmt.Errorf("invalid KV key %q: numeric identifier %q has the wrong length", k, id)
+			}
+			ids[i] = id[4:]
+		case strings.HasPrefix(id, "1") && !isNumeric(id[1:]):
+			ids[i] = id[1:]
+		default:
+			return Semver{}, fmt.Errorf("invalid KV key %q: malformed identifier %q", k, id)
+		}
+	}
+	ver.Prerelease = strings.Join(ids, ".")
+	return ver, nil
+}
+
+// MarshalText implements encoding.TextMarshaler, encoding s in its canonical form as
+// returned by String.
+func (s Semver) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. The text must be a valid version as
+// ParseStrict defines it, optionally with a "v" prefix, so malformed input from a file or
+// a database is rejected rather than stored and written back out.
+func (s *Semver) UnmarshalText(text []byte) error {
+	ver, err := ParseStrict(strings.TrimPrefix(string(text), "v"))
+	if err != nil {
+		return err
+	}
+	*s = ver
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, encoding s as a JSON string in its canonical
+// form.
+//
+// Example:
+//
+//	data, _ := json.Marshal(Semver{Major: 1, Minor: 2, Patch: 3, Prerelease: "rc.1"})
+//	fmt.Println(string(data)) // prints "1.2.3-rc.1"
+func (s Semver) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, decoding a JSON string as UnmarshalText
+// does. A JSON null leaves s unchanged.
+func (s *Semver) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return fmt.Errorf("version must be a JSON string: %w", err)
+	}
+	return s.UnmarshalText([]byte(text))
+}
+
+// Scan implements sql.Scanner, reading a version stored as text. A NULL column is an
+// error, since Semver has no null value.
+func (s *Semver) Scan(src any) error {
+	switch src := src.(type) {
+	case string:
+		return s.UnmarshalText([]byte(src))
+	case []byte:
+		return s.UnmarshalText(src)
+	case nil:
+		return fmt.Errorf("cannot scan NULL into Semver")
+	}
+	return fmt.Errorf("cannot scan %T into Semver", src)
+}
+
+// Value implements driver.Valuer, storing s as text in its canonical form.
+func (s Semver) Value() (driver.Value, error) {
+	return s.String(), nil
+}
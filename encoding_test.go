@@ -0,0 +1,305 @@
+package semver
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestParseJSONArray(t *testing.T) {
+	vers, err := ParseJSONArray([]byte(`["1.0.0", "v1.1.0", "2.0.0-rc.1+001"]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []Semver{
+		{Major: 1},
+		{Major: 1, Minor: 1},
+		{Major: 2, Prerelease: "rc.1", Meta: "001"},
+	}
+	if len(vers) != len(expected) {
+		t.Fatalf("expected %d versions but got %d", len(expected), len(vers))
+	}
+	for i := range expected {
+		if vers[i] != expected[i] {
+			t.Errorf("expected element %d to be %+v but got %+v", i, expected[i], vers[i])
+		}
+	}
+
+	_, err = ParseJSONArray([]byte(`["1.0.0", "1.1.0", "latest"]`))
+	if err == nil || !strings.Contains(err.Error(), "element 2") {
+		t.Errorf("expected an error identifying element 2 but got %v", err)
+	}
+
+	if _, err := ParseJSONArray([]byte(`{"version": "1.0.0"}`)); err == nil {
+		t.Error("expected an error for a non-array document")
+	}
+}
+
+func TestParseTSV(t *testing.T) {
+	input := "api\t1.2.3\n\nweb\tv2.0.0-rc.1\n"
+	deps, err := ParseTSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]Semver{
+		"api": {Major: 1, Minor: 2, Patch: 3},
+		"web": {Major: 2, Prerelease: "rc.1"},
+	}
+	if len(deps) != len(expected) {
+		t.Fatalf("expected %d entries but got %d", len(expected), len(deps))
+	}
+	for name, ver := range expected {
+		if deps[name] != ver {
+			t.Errorf("expected %s to be %+v but got %+v", name, ver, deps[name])
+		}
+	}
+
+	malformed := "api\t1.2.3\nweb 2.0.0\n"
+	_, err = ParseTSV(strings.NewReader(malformed))
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("expected an error identifying line 2 but got %v", err)
+	}
+
+	invalid := "api\t1.2.3\n\ndb\tlatest\n"
+	_, err = ParseTSV(strings.NewReader(invalid))
+	if err == nil || !strings.Contains(err.Error(), "line 3") {
+		t.Errorf("expected an error identifying line 3 but got %v", err)
+	}
+}
+
+func TestMarshalSortedJSON(t *testing.T) {
+	data, err := MarshalSortedJSON([]string{"v1.10.0", "1.2.0", "v1.2.0-rc.1", "1.02.1+build"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `["1.2.0-rc.1","1.2.0","1.2.1+build","1.10.0"]`; string(data) != expected {
+		t.Errorf("expected %s but got %s", expected, data)
+	}
+
+	data, err = MarshalSortedJSON([]string{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "[]" {
+		t.Errorf("expected an empty array but got %s", data)
+	}
+
+	if _, err := MarshalSortedJSON([]string{"1.0.0", "nope"}); err == nil {
+		t.Error("expected an error for an invalid version")
+	}
+}
+
+func TestStreamMaxMin(t *testing.T) {
+	var b strings.Builder
+	for i := 0; i < 10000; i++ {
+		fmt.Fprintf(&b, "%d.%d.%d\n", i%7, i%13, i%101)
+		if i%1000 == 0 {
+			b.WriteString("\n")
+		}
+	}
+	b.WriteString("  v7.0.0-rc.1  \n7.0.0-beta\n0.0.0-alpha\n")
+
+	latest, err := StreamMax(strings.NewReader(b.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latest != "v7.0.0-rc.1" {
+		t.Errorf("expected max to be v7.0.0-rc.1 but got %s", latest)
+	}
+
+	oldest, err := StreamMin(strings.NewReader(b.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if oldest != "0.0.0-alpha" {
+		t.Errorf("expected min to be 0.0.0-alpha but got %s", oldest)
+	}
+
+	_, err = StreamMax(strings.NewReader("1.0.0\n\nnope\n"))
+	if err == nil || !strings.Contains(err.Error(), "line 3") {
+		t.Errorf("expected an error for line 3 but got %v", err)
+	}
+	if _, err := StreamMin(strings.NewReader("\n\n")); err == nil {
+		t.Error("expected an error for empty input")
+	}
+}
+
+func TestMaxInCSVColumn(t *testing.T) {
+	data := "api,1.2.0,2023-01-05\nweb, v1.10.0 ,2023-02-01\n\"worker, eu\",1.9.3,2023-03-01\ncli,1.10.0-rc.1,2023-01-20\n"
+
+	latest, err := MaxInCSVColumn(strings.NewReader(data), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latest != "v1.10.0" {
+		t.Errorf("expected max to be v1.10.0 but got %s", latest)
+	}
+
+	tests := []struct {
+		data   string
+		column int
+		row    string
+	}{
+		{"api,1.2.0\nweb,next\n", 1, "row 2"},
+		{"api,1.2.0\nweb\n", 1, "row 2"},
+		{"api,1.2.0\n\"web,1.3.0\n", 1, "row 2"},
+		{"api,1.2.0\n", 5, "row 1"},
+	}
+
+	for _, test := range tests {
+		_, err := MaxInCSVColumn(strings.NewReader(test.data), test.column)
+		if err == nil || !strings.Contains(err.Error(), test.row) {
+			t.Errorf("expected an error for %s of %q but got %v", test.row, test.data, err)
+		}
+	}
+
+	if _, err := MaxInCSVColumn(strings.NewReader(""), 0); err == nil {
+		t.Error("expected an error for empty input")
+	}
+}
+
+func TestKVKey(t *testing.T) {
+	versions := []string{
+		"1.0.0",
+		"1.2.3-rc.1+build.5",
+		"0.0.0",
+		"10.20.30-alpha.beta.0",
+		"1.0.0-x-y.7+exp.sha.5114f85",
+		"1.0.0--",
+	}
+
+	for _, v := range versions {
+		ver, err := ParseVersion(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		decoded, err := FromKVKey(ver.KVKey())
+		if err != nil {
+			t.Error(err)
+		}
+		if decoded != ver {
+			t.Errorf("expected %s to round-trip through %s but got %+v", v, ver.KVKey(), decoded)
+		}
+	}
+
+	// the example chain from https://semver.org/#spec-item-11, across several cores
+	chain := []string{
+		"0.9.0",
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.0+build",
+		"1.9.0",
+		"1.10.0",
+	}
+
+	keys := make([]string, len(chain))
+	for i, v := range chain {
+		ver, err := ParseVersion(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys[len(chain)-1-i] = ver.KVKey()
+	}
+	sort.Strings(keys)
+
+	for i, key := range keys {
+		ver, err := FromKVKey(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ver.String() != chain[i] {
+			t.Errorf("expected key %d to decode to %s but got %s", i, chain[i], ver)
+		}
+	}
+
+	for _, bad := range []string{
+		"",
+		"1.2.3",
+		"0000000000000000001.0000000000000000002.0000000000000000003",
+		"0000000000000000001.0000000000000000002.0000000000000000003*",
+		"0000000000000000001.0000000000000000002.0000000000000000003~rc",
+		"0000000000000000001.0000000000000000002.0000000000000000003-",
+		"0000000000000000001.0000000000000000002.0000000000000000003-00021",
+		"0000000000000000001.0000000000000000002.0000000000000000003-2rc",
+		"0000000000000000001.0000000000000000002.0000000000000000003~+",
+	} {
+		if _, err := FromKVKey(bad); err == nil {
+			t.Errorf("expected an error for %q", bad)
+		}
+	}
+}
+
+func TestMarshaling(t *testing.T) {
+	type config struct {
+		Version Semver  `json:"version"`
+		Minimum *Semver `json:"minimum,omitempty"`
+	}
+
+	ver := Semver{Major: 1, Minor: 2, Patch: 3, Prerelease: "rc.1", Meta: "build.5"}
+	data, err := json.Marshal(config{Version: ver})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `{"version":"1.2.3-rc.1+build.5"}`; string(data) != expected {
+		t.Errorf("expected %s but got %s", expected, data)
+	}
+
+	var decoded config
+	if err := json.Unmarshal([]byte(`{"version":"v2.0.0","minimum":"1.0.0"}`), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Version != (Semver{Major: 2}) || decoded.Minimum == nil || *decoded.Minimum != (Semver{Major: 1}) {
+		t.Errorf("unexpected decoded config %+v", decoded)
+	}
+
+	for _, bad := range []string{`{"version":"1.2"}`, `{"version":123}`, `{"version":"not1.2.3really"}`, `{"version":"1.2.3-a!b"}`, `{"version":"1.2.3+build..5"}`, `{"version":"01.2.3"}`} {
+		if err := json.Unmarshal([]byte(bad), &decoded); err == nil {
+			t.Errorf("expected an error for %s", bad)
+		}
+	}
+
+	text, err := ver.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fromText Semver
+	if err := fromText.UnmarshalText(text); err != nil || fromText != ver {
+		t.Errorf("expected %s to round-trip through text but got %+v (%v)", ver, fromText, err)
+	}
+}
+
+func TestSQL(t *testing.T) {
+	ver := Semver{Major: 1, Minor: 2, Patch: 3, Prerelease: "rc.1"}
+	value, err := ver.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != "1.2.3-rc.1" {
+		t.Errorf("expected value 1.2.3-rc.1 but got %v", value)
+	}
+
+	for _, src := range []any{"1.2.3-rc.1", []byte("1.2.3-rc.1")} {
+		var scanned Semver
+		if err := scanned.Scan(src); err != nil {
+			t.Error(err)
+		}
+		if scanned != ver {
+			t.Errorf("expected %T %v to scan as %+v but got %+v", src, src, ver, scanned)
+		}
+	}
+
+	for _, src := range []any{nil, 42, "latest", "1.2.3-a!b", []byte("1.2.3-rc.01")} {
+		var scanned Semver
+		if err := scanned.Scan(src); err == nil {
+			t.Errorf("expected an error scanning %v", src)
+		}
+	}
+}
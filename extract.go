@@ -0,0 +1,409 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ErrRangeSpec is returned by ParseNpmSpec when a spec names a version range, such as
+// "pkg@^1.2.0", instead of an exact version. Parse the range with ParseConstraint.
+var ErrRangeSpec = errors.New("spec names a version range")
+
+// revRe matches a trailing revision suffix such as "-rev4".
+var revRe = regexp.MustCompile(`-rev(\d+)$`)
+
+// protoOptionRe matches a protobuf option with a string value, such as
+// `option (my.version) = "1.2.3";`.
+var protoOptionRe = regexp.MustCompile(`^\s*option\s+(\([\w.]+\)|[\w.]+)\s*=\s*"([^"]*)"\s*;`)
+
+// TagOptions controls which lines ParseTags accepts.
+type TagOptions struct {
+	// RequirePrefix skips tags without a leading "v", such as "1.2.3" in a repository that
+	// tags releases as "v1.2.3".
+	RequirePrefix bool
+
+	// SkipPrereleases skips tags with a prerelease tag.
+	SkipPrereleases bool
+
+	// OnSkip, when set, is called with every non-blank line that is skipped, so callers can
+	// report tags that were ignored.
+	OnSkip func(line string)
+}
+
+// ParseTags turns the output of "git tag" into a clean list of versions, in the order of
+// the lines. Surrounding whitespace and blank lines are ignored, and the "v" prefix is
+// optional unless opts requires it. Lines that are not versions, such as "latest" or
+// "release-2024", are skipped rather than treated as errors.
+//
+// Tags that look like versions but have a malformed core, such as "v1..3" or one using
+// non-ASCII digits, are not skipped: they usually point to a typo or a spoofed tag.
+//
+// If such a tag is found, the function returns nil and an error naming its line.
+//
+// Example:
+//
+//	vers, err := ParseTags([]string{"v1.0.0", "v1.1.0-rc.1", "latest"}, TagOptions{SkipPrereleases: true})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(vers) // prints [1.0.0]
+func ParseTags(lines []string, opts TagOptions) ([]Semver, error) {
+	skip := func(line string) {
+		if opts.OnSkip != nil {
+			opts.OnSkip(line)
+		}
+	}
+
+	vers := []Semver{}
+	for i, line := range lines {
+		text := strings.TrimSpace(line)
+		if text == "" {
+			continue
+		}
+		if opts.RequirePrefix && !strings.HasPrefix(text, "v") {
+			skip(line)
+			continue
+		}
+
+		v, err := ParseVersion(strings.TrimPrefix(text, "v"))
+		if err != nil {
+			if errors.Is(err, ErrEmptyComponent) || errors.Is(err, ErrNegativeComponent) || errors.Is(err, ErrNonASCIIDigit) {
+				return nil, fmt.Errorf("line %d: %w", i+1, err)
+			}
+			skip(line)
+			continue
+		}
+		if opts.SkipPrereleases && v.Prerelease != "" {
+			skip(line)
+			continue
+		}
+		vers = append(vers, v)
+	}
+	return vers, nil
+}
+
+// ParseModuleVersion takes a Java module style string such as "mymodule_1.2.3" and
+// splits it into the module name and the parsed version.
+//
+// The string is split on its last underscore; everything before it is the name and
+// everything after it must be a version. A string without an underscore is parsed as a
+// bare version with an empty name.
+//
+// If no version can be parsed, the function returns an empty name, an empty Semver
+// structure, and an error.
+//
+// Example:
+//
+//	name, ver, err := ParseModuleVersion("mymodule_1.2.3")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(name, ver.Minor) // prints mymodule 2
+func ParseModuleVersion(s string) (name string, v Semver, err error) {
+	rest := s
+	if i := strings.LastIndex(s, "_"); i >= 0 {
+		name, rest = s[:i], s[i+1:]
+	}
+
+	v, err = ParseVersion(rest)
+	if err != nil {
+		return "", Semver{}, fmt.Errorf("no version found in %q: %w", s, err)
+	}
+
+	return name, v, nil
+}
+
+// ParseEcosystem takes an inventory entry such as "npm:1.2.3" or "pypi:1.2.3" and
+// splits it into the package ecosystem and the parsed version.
+//
+// The string is split on its first colon; everything before it is the ecosystem and
+// everything after it must be a version. A string without a colon is parsed as a bare
+// version with an empty ecosystem.
+//
+// If no version can be parsed, the function returns an empty ecosystem, an empty Semver
+// structure, and an error.
+//
+// Example:
+//
+//	ecosystem, ver, err := ParseEcosystem("npm:1.2.3")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(ecosystem, ver) // prints npm 1.2.3
+func ParseEcosystem(s string) (ecosystem string, v Semver, err error) {
+	rest := s
+	if before, after, found := strings.Cut(s, ":"); found {
+		ecosystem, rest = before, after
+	}
+
+	v, err = ParseVersion(rest)
+	if err != nil {
+		return "", Semver{}, fmt.Errorf("no version found in %q: %w", s, err)
+	}
+
+	return ecosystem, v, nil
+}
+
+// ParseFilename takes a file name such as "myapp-1.2.3.tar.gz", strips a known prefix
+// and suffix, and parses what remains as a version.
+//
+// If name does not start with prefix or end with suffix, or the remainder is not a
+// version, the function returns an empty Semver structure and an error.
+//
+// Example:
+//
+//	ver, err := ParseFilename("myapp-1.2.3.tar.gz", "myapp-", ".tar.gz")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(ver) // prints 1.2.3
+func ParseFilename(name, prefix, suffix string) (Semver, error) {
+	if !strings.HasPrefix(name, prefix) {
+		return Semver{}, fmt.Errorf("%q does not start with %q", name, prefix)
+	}
+	if !strings.HasSuffix(name, suffix) || len(name) < len(prefix)+len(suffix) {
+		return Semver{}, fmt.Errorf("%q does not end with %q", name, suffix)
+	}
+
+	v, err := ParseVersion(name[len(prefix) : len(name)-len(suffix)])
+	if err != nil {
+		return Semver{}, fmt.Errorf("no version found in %q: %w", name, err)
+	}
+	return v, nil
+}
+
+// ParseNpmSpec takes an npm package spec such as "@org/pkg@1.2.3" or "pkg@1.2.3" and
+// splits it into the package name and the parsed version.
+//
+// The version follows the last "@" that does not start a scope. Specs with a range, such
+// as "pkg@^1.2.0", return an error wrapping ErrRangeSpec so callers can fall back to
+// ParseConstraint.
+//
+// If the spec has no version or the version cannot be parsed, the function returns an
+// empty name, an empty Semver structure, and an error.
+//
+// Example:
+//
+//	name, ver, err := ParseNpmSpec("@org/pkg@1.2.3")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(name, ver) // prints @org/pkg 1.2.3
+func ParseNpmSpec(s string) (name string, v Semver, err error) {
+	i := strings.LastIndex(s, "@")
+	if i <= 0 {
+		return "", Semver{}, fmt.Errorf("no version in spec %q", s)
+	}
+	name, text := s[:i], s[i+1:]
+	if strings.HasPrefix(name, "@") && !strings.Contains(name, "/") {
+		return "", Semver{}, fmt.Errorf("invalid scoped package name %q", name)
+	}
+
+	v, err = ParseVersion(text)
+	if err != nil {
+		if _, cerr := ParseConstraint(text); cerr == nil {
+			return "", Semver{}, fmt.Errorf("%w: %q", ErrRangeSpec, s)
+		}
+		return "", Semver{}, fmt.Errorf("invalid version in spec %q: %w", s, err)
+	}
+
+	return name, v, nil
+}
+
+// ParseLabeled takes a changelog style string such as "Release 1.2.3" or "Hotfix v1.2.3"
+// and splits it into the leading label and the parsed version.
+//
+// The label is the first whitespace-separated word and the version is the rest of the
+// string, with an optional "v" prefix. A string holding only a version has an empty label.
+//
+// If no version can be parsed, the function returns an empty label, an empty Semver
+// structure, and an error.
+//
+// Example:
+//
+//	label, ver, err := ParseLabeled("Hotfix v1.2.3")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(label, ver) // prints Hotfix 1.2.3
+func ParseLabeled(s string) (label string, v Semver, err error) {
+	fields := strings.Fields(s)
+	var text string
+	switch len(fields) {
+	case 1:
+		text = fields[0]
+	case 2:
+		label, text = fields[0], fields[1]
+	default:
+		return "", Semver{}, fmt.Errorf("expected a label and a version in %q", s)
+	}
+
+	v, err = ParseVersion(strings.TrimPrefix(text, "v"))
+	if err != nil {
+		return "", Semver{}, fmt.Errorf("no version found in %q: %w", s, err)
+	}
+	return label, v, nil
+}
+
+// ParseAnnotated takes a version followed by an optional parenthesized annotation, such
+// as "1.2.3 (stable)" or "v1.2.3 (deprecated)", and returns the parsed version and the
+// annotation without its parentheses.
+//
+// Inputs without an annotation return an empty annotation. The version may carry a "v"
+// prefix.
+//
+// If the version cannot be parsed, the function returns an empty Semver structure, an
+// empty annotation, and an error.
+//
+// Example:
+//
+//	ver, note, err := ParseAnnotated("1.2.3 (deprecated)")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(ver, note) // prints 1.2.3 deprecated
+func ParseAnnotated(s string) (Semver, string, error) {
+	text := strings.TrimSpace(s)
+
+	var annotation string
+	if strings.HasSuffix(text, ")") {
+		if open := strings.LastIndex(text, "("); open >= 0 {
+			annotation = strings.TrimSpace(text[open+1 : len(text)-1])
+			text = strings.TrimSpace(text[:open])
+		}
+	}
+
+	v, err := ParseVersion(strings.TrimPrefix(text, "v"))
+	if err != nil {
+		return Semver{}, "", fmt.Errorf("no version found in %q: %w", s, err)
+	}
+	return v, annotation, nil
+}
+
+// ParseKeyValue scans text line by line for the first "key: value" pair with the given
+// key, as found in YAML front matter, and parses its value as a version. Surrounding
+// whitespace and matching single or double quotes around the value are removed.
+//
+// If the key is missing or its value cannot be parsed, the function returns an empty
+// Semver structure and an error.
+//
+// Example:
+//
+//	ver, err := ParseKeyValue("---\ntitle: Notes\nversion: 1.2.3\n---", "version")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(ver) // prints 1.2.3
+func ParseKeyValue(text, key string) (Semver, error) {
+	for _, line := range strings.Split(text, "\n") {
+		k, value, ok := strings.Cut(line, ":")
+		if !ok || strings.TrimSpace(k) != key {
+			continue
+		}
+
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		v, err := parse(value)
+		if err != nil {
+			return Semver{}, fmt.Errorf("invalid %s %q: %w", key, value, err)
+		}
+		return v, nil
+	}
+	return Semver{}, fmt.Errorf("key %q not found", key)
+}
+
+// ParseProtoVersion extracts the version from a protobuf option line such as
+// `option (my.version) = "1.2.3";`. Both custom options in parentheses and plain option
+// names are accepted, and trailing comments are ignored. The quoted value may carry a "v"
+// prefix.
+//
+// If the line is not an option with a string value, or the value is not a version, the
+// function returns an empty Semver structure and an error.
+//
+// Example:
+//
+//	ver, err := ParseProtoVersion(`option (my.version) = "1.2.3";`)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(ver) // prints 1.2.3
+func ParseProtoVersion(line string) (Semver, error) {
+	m := protoOptionRe.FindStringSubmatch(line)
+	if m == nil {
+		return Semver{}, fmt.Errorf("no version option found in %q", line)
+	}
+
+	v, err := ParseVersion(strings.TrimPrefix(m[2], "v"))
+	if err != nil {
+		return Semver{}, fmt.Errorf("invalid version option %s in %q: %w", m[1], line, err)
+	}
+	return v, nil
+}
+
+// ParseRevTag parses an internal revision tag such as "v1.2.3-rev4" into the version and
+// its revision number. The "v" prefix is optional, and a tag without a trailing "-revN"
+// has revision 0. The revision is not a prerelease tag: "v1.2.3-rev4" parses as 1.2.3
+// with revision 4. Any other suffix is parsed as usual, so "1.2.3-rc.1-rev2" has the
+// prerelease tag "rc.1" and revision 2.
+//
+// If the tag cannot be parsed, the function returns an empty Semver structure, 0, and an
+// error.
+//
+// Example:
+//
+//	ver, rev, err := ParseRevTag("v1.2.3-rev4")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(ver, rev) // prints 1.2.3 4
+func ParseRevTag(s string) (Semver, int, error) {
+	text := strings.TrimPrefix(s, "v")
+
+	rev := 0
+	if loc := revRe.FindStringSubmatchIndex(text); loc != nil {
+		n, err := strconv.Atoi(text[loc[2]:loc[3]])
+		if err != nil {
+			return Semver{}, 0, fmt.Errorf("invalid revision in %q: %w", s, err)
+		}
+		rev, text = n, text[:loc[0]]
+	}
+
+	v, err := ParseVersion(text)
+	if err != nil {
+		return Semver{}, 0, fmt.Errorf("invalid rev tag %q: %w", s, err)
+	}
+	return v, rev, nil
+}
+
+// CompareRevTags compares two revision tags as parsed by ParseRevTag, ordering them by
+// version precedence first and revision second.
+//
+// If there is an error parsing either tag, the function returns 0 and the error.
+//
+// Example:
+//
+//	result, err := CompareRevTags("v1.2.3-rev4", "v1.2.3-rev5")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(result) // prints -1
+func CompareRevTags(a, b string) (int, error) {
+	ver1, rev1, err := ParseRevTag(a)
+	if err != nil {
+		return 0, err
+	}
+	ver2, rev2, err := ParseRevTag(b)
+	if err != nil {
+		return 0, err
+	}
+
+	if result := compare(ver1, ver2); result != 0 {
+		return result, nil
+	}
+	return compareInts(rev1, rev2), nil
+}
@@ -0,0 +1,341 @@
+package semver
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseTags(t *testing.T) {
+	lines := []string{"v1.0.0", "v1.1.0-rc.1", "latest", "", "1.2.0", "release-2024", "  v1.2.1\r"}
+
+	tests := []struct {
+		opts     TagOptions
+		expected []string
+		skipped  []string
+	}{
+		{TagOptions{}, []string{"1.0.0", "1.1.0-rc.1", "1.2.0", "1.2.1"}, []string{"latest", "release-2024"}},
+		{TagOptions{SkipPrereleases: true}, []string{"1.0.0", "1.2.0", "1.2.1"}, []string{"v1.1.0-rc.1", "latest", "release-2024"}},
+		{TagOptions{RequirePrefix: true}, []string{"1.0.0", "1.1.0-rc.1", "1.2.1"}, []string{"latest", "1.2.0", "release-2024"}},
+	}
+
+	for _, test := range tests {
+		var skipped []string
+		test.opts.OnSkip = func(line string) { skipped = append(skipped, line) }
+
+		vers, err := ParseTags(lines, test.opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := make([]string, len(vers))
+		for i, v := range vers {
+			got[i] = v.String()
+		}
+		if strings.Join(got, " ") != strings.Join(test.expected, " ") {
+			t.Errorf("expected %v with %+v but got %v", test.expected, test.opts, got)
+		}
+		if strings.Join(skipped, " ") != strings.Join(test.skipped, " ") {
+			t.Errorf("expected to skip %v with %+v but skipped %v", test.skipped, test.opts, skipped)
+		}
+	}
+
+	if _, err := ParseTags([]string{"v1.0.0", "v1..3"}, TagOptions{}); !errors.Is(err, ErrEmptyComponent) || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("expected a malformed tag error on line 2 but got %v", err)
+	}
+}
+
+func TestParseModuleVersion(t *testing.T) {
+	tests := []struct {
+		s    string
+		name string
+		v    Semver
+	}{
+		{"mymodule_1.2.3", "mymodule", Semver{Major: 1, Minor: 2, Patch: 3}},
+		{"my_module_2.0.0-rc.1", "my_module", Semver{Major: 2, Prerelease: "rc.1"}},
+		{"1.2.3", "", Semver{Major: 1, Minor: 2, Patch: 3}},
+	}
+
+	for _, test := range tests {
+		name, v, err := ParseModuleVersion(test.s)
+		if err != nil {
+			t.Error(err)
+		}
+		if name != test.name || v != test.v {
+			t.Errorf("expected %s to be %q %+v but got %q %+v", test.s, test.name, test.v, name, v)
+		}
+	}
+
+	for _, bad := range []string{"mymodule", "mymodule_", "mymodule_latest", "mymodule_1.2"} {
+		if _, _, err := ParseModuleVersion(bad); err == nil {
+			t.Errorf("expected an error for %q", bad)
+		}
+	}
+}
+
+func TestParseEcosystem(t *testing.T) {
+	tests := []struct {
+		s         string
+		ecosystem string
+		v         Semver
+	}{
+		{"npm:1.2.3", "npm", Semver{Major: 1, Minor: 2, Patch: 3}},
+		{"pypi:2.0.0-rc.1+build", "pypi", Semver{Major: 2, Prerelease: "rc.1", Meta: "build"}},
+		{"1.2.3", "", Semver{Major: 1, Minor: 2, Patch: 3}},
+	}
+
+	for _, test := range tests {
+		ecosystem, v, err := ParseEcosystem(test.s)
+		if err != nil {
+			t.Error(err)
+		}
+		if ecosystem != test.ecosystem || v != test.v {
+			t.Errorf("expected %s to be %q %+v but got %q %+v", test.s, test.ecosystem, test.v, ecosystem, v)
+		}
+	}
+
+	for _, bad := range []string{"npm", "npm:", "npm:latest", "maven:org:1.2.3"} {
+		if _, _, err := ParseEcosystem(bad); err == nil {
+			t.Errorf("expected an error for %q", bad)
+		}
+	}
+}
+
+func TestParseFilename(t *testing.T) {
+	tests := []struct {
+		name     string
+		prefix   string
+		suffix   string
+		expected Semver
+	}{
+		{"myapp-1.2.3.tar.gz", "myapp-", ".tar.gz", Semver{Major: 1, Minor: 2, Patch: 3}},
+		{"myapp-1.2.3-rc.1.zip", "myapp-", ".zip", Semver{Major: 1, Minor: 2, Patch: 3, Prerelease: "rc.1"}},
+		{"1.2.3", "", "", Semver{Major: 1, Minor: 2, Patch: 3}},
+	}
+
+	for _, test := range tests {
+		v, err := ParseFilename(test.name, test.prefix, test.suffix)
+		if err != nil {
+			t.Error(err)
+		}
+		if v != test.expected {
+			t.Errorf("expected %s to be %+v but got %+v", test.name, test.expected, v)
+		}
+	}
+
+	bad := [][3]string{
+		{"otherapp-1.2.3.tar.gz", "myapp-", ".tar.gz"},
+		{"myapp-1.2.3.zip", "myapp-", ".tar.gz"},
+		{"myapp-latest.tar.gz", "myapp-", ".tar.gz"},
+		{"myapp.tar.gz", "myapp.tar", "tar.gz"},
+	}
+	for _, b := range bad {
+		if _, err := ParseFilename(b[0], b[1], b[2]); err == nil {
+			t.Errorf("expected an error for %q with prefix %q and suffix %q", b[0], b[1], b[2])
+		}
+	}
+}
+
+func TestParseNpmSpec(t *testing.T) {
+	tests := []struct {
+		s    string
+		name string
+		v    Semver
+	}{
+		{"@org/pkg@1.2.3", "@org/pkg", Semver{Major: 1, Minor: 2, Patch: 3}},
+		{"pkg@1.2.3", "pkg", Semver{Major: 1, Minor: 2, Patch: 3}},
+		{"pkg@2.0.0-rc.1", "pkg", Semver{Major: 2, Prerelease: "rc.1"}},
+	}
+
+	for _, test := range tests {
+		name, v, err := ParseNpmSpec(test.s)
+		if err != nil {
+			t.Error(err)
+		}
+		if name != test.name || v != test.v {
+			t.Errorf("expected %s to be %q %+v but got %q %+v", test.s, test.name, test.v, name, v)
+		}
+	}
+
+	for _, spec := range []string{"pkg@^1.2.0", "@org/pkg@~1.2", "pkg@>=1.0.0 <2.0.0"} {
+		if _, _, err := ParseNpmSpec(spec); !errors.Is(err, ErrRangeSpec) {
+			t.Errorf("expected %q to fail with %v but got %v", spec, ErrRangeSpec, err)
+		}
+	}
+
+	for _, bad := range []string{"pkg", "@org/pkg", "@org@1.2.3", "pkg@latest"} {
+		if _, _, err := ParseNpmSpec(bad); err == nil || errors.Is(err, ErrRangeSpec) {
+			t.Errorf("expected a non-range error for %q but got %v", bad, err)
+		}
+	}
+}
+
+func TestParseLabeled(t *testing.T) {
+	tests := []struct {
+		s     string
+		label string
+		v     Semver
+	}{
+		{"Release 1.2.3", "Release", Semver{Major: 1, Minor: 2, Patch: 3}},
+		{"Hotfix v1.2.3", "Hotfix", Semver{Major: 1, Minor: 2, Patch: 3}},
+		{"  Beta   2.0.0-beta.1 ", "Beta", Semver{Major: 2, Prerelease: "beta.1"}},
+		{"1.2.3", "", Semver{Major: 1, Minor: 2, Patch: 3}},
+	}
+
+	for _, test := range tests {
+		label, v, err := ParseLabeled(test.s)
+		if err != nil {
+			t.Error(err)
+		}
+		if label != test.label || v != test.v {
+			t.Errorf("expected %q to be %q %+v but got %q %+v", test.s, test.label, test.v, label, v)
+		}
+	}
+
+	for _, bad := range []string{"", "Release", "Release notes for 1.2.3", "Release 1.2"} {
+		if _, _, err := ParseLabeled(bad); err == nil {
+			t.Errorf("expected an error for %q", bad)
+		}
+	}
+}
+
+func TestParseAnnotated(t *testing.T) {
+	tests := []struct {
+		s          string
+		v          Semver
+		annotation string
+	}{
+		{"1.2.3 (stable)", Semver{Major: 1, Minor: 2, Patch: 3}, "stable"},
+		{"v1.2.3-rc.1 ( deprecated )", Semver{Major: 1, Minor: 2, Patch: 3, Prerelease: "rc.1"}, "deprecated"},
+		{"1.2.3(lts)", Semver{Major: 1, Minor: 2, Patch: 3}, "lts"},
+		{"1.2.3", Semver{Major: 1, Minor: 2, Patch: 3}, ""},
+	}
+
+	for _, test := range tests {
+		v, annotation, err := ParseAnnotated(test.s)
+		if err != nil {
+			t.Error(err)
+		}
+		if v != test.v || annotation != test.annotation {
+			t.Errorf("expected %q to be %+v %q but got %+v %q", test.s, test.v, test.annotation, v, annotation)
+		}
+	}
+
+	for _, bad := range []string{"", "(stable)", "1.2 (stable)", "1.2.3 stable"} {
+		if _, _, err := ParseAnnotated(bad); err == nil {
+			t.Errorf("expected an error for %q", bad)
+		}
+	}
+}
+
+func TestParseKeyValue(t *testing.T) {
+	text := "---\ntitle: Release notes\nauthor: someone\nversion: 1.2.3\napi_version: \"v2.0.0-rc.1\"\n---\n# Notes\n"
+
+	tests := []struct {
+		key      string
+		expected Semver
+	}{
+		{"version", Semver{Major: 1, Minor: 2, Patch: 3}},
+		{"api_version", Semver{Major: 2, Prerelease: "rc.1"}},
+	}
+
+	for _, test := range tests {
+		v, err := ParseKeyValue(text, test.key)
+		if err != nil {
+			t.Error(err)
+		}
+		if v != test.expected {
+			t.Errorf("expected %s to be %+v but got %+v", test.key, test.expected, v)
+		}
+	}
+
+	if _, err := ParseKeyValue(text, "release"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("expected a missing key error but got %v", err)
+	}
+	if _, err := ParseKeyValue(text, "title"); err == nil {
+		t.Error("expected an error for a value that is not a version")
+	}
+}
+
+func TestParseProtoVersion(t *testing.T) {
+	tests := []struct {
+		line     string
+		expected Semver
+	}{
+		{`option (my.version) = "1.2.3";`, Semver{Major: 1, Minor: 2, Patch: 3}},
+		{`  option (acme.api.version)="v2.0.0-rc.1"; // bumped by CI`, Semver{Major: 2, Prerelease: "rc.1"}},
+		{`option api_version = "0.4.1";`, Semver{Minor: 4, Patch: 1}},
+	}
+
+	for _, test := range tests {
+		v, err := ParseProtoVersion(test.line)
+		if err != nil {
+			t.Error(err)
+		}
+		if v != test.expected {
+			t.Errorf("expected %q to give %+v but got %+v", test.line, test.expected, v)
+		}
+	}
+
+	for _, bad := range []string{
+		`option go_package = "example.com/pb";`,
+		`syntax = "proto3";`,
+		`option (my.version) = 1;`,
+		`// option (my.version) = "1.2.3";`,
+	} {
+		if _, err := ParseProtoVersion(bad); err == nil {
+			t.Errorf("expected an error for %q", bad)
+		}
+	}
+}
+
+func TestParseRevTag(t *testing.T) {
+	tests := []struct {
+		s   string
+		v   Semver
+		rev int
+	}{
+		{"v1.2.3-rev4", Semver{Major: 1, Minor: 2, Patch: 3}, 4},
+		{"1.2.3", Semver{Major: 1, Minor: 2, Patch: 3}, 0},
+		{"v1.2.3-rc.1-rev2", Semver{Major: 1, Minor: 2, Patch: 3, Prerelease: "rc.1"}, 2},
+		{"1.2.3-revision", Semver{Major: 1, Minor: 2, Patch: 3, Prerelease: "revision"}, 0},
+	}
+
+	for _, test := range tests {
+		v, rev, err := ParseRevTag(test.s)
+		if err != nil {
+			t.Error(err)
+		}
+		if v != test.v || rev != test.rev {
+			t.Errorf("expected %s to be %+v rev %d but got %+v rev %d", test.s, test.v, test.rev, v, rev)
+		}
+	}
+
+	if _, _, err := ParseRevTag("v1.2-rev4"); err == nil {
+		t.Error("expected an error for an invalid core")
+	}
+}
+
+func TestCompareRevTags(t *testing.T) {
+	tests := []struct {
+		a        string
+		b        string
+		expected int
+	}{
+		{"v1.2.3-rev4", "v1.2.3-rev5", -1},
+		{"v1.2.3-rev5", "v1.2.3-rev4", 1},
+		{"v1.2.3-rev10", "v1.2.3-rev9", 1},
+		{"v1.2.3-rev4", "1.2.3-rev4", 0},
+		{"v1.2.3-rev9", "v1.2.4-rev1", -1},
+		{"v1.2.3", "v1.2.3-rev1", -1},
+	}
+
+	for _, test := range tests {
+		result, err := CompareRevTags(test.a, test.b)
+		if err != nil {
+			t.Error(err)
+		}
+		if result != test.expected {
+			t.Errorf("expected %s and %s to be %d but got %d", test.a, test.b, test.expected, result)
+		}
+	}
+}
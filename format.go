@@ -0,0 +1,205 @@
+package semver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// String returns the canonical form of s: MAJOR.MINOR.PATCH, followed by "-" and the
+// prerelease tag and "+" and the metadata when they are present. The result parses back
+// into an equal Semver structure with ParseVersion.
+//
+// Example:
+//
+//	ver, _ := ParseVersion("1.2.3-rc.1")
+//	ver.Minor++
+//	ver.Prerelease = ""
+//	fmt.Println(ver) // prints 1.3.3
+func (s Semver) String() string {
+	return s.FormatWith(".")
+}
+
+// Fields returns the components of s as a map for structured loggers. The map always
+// holds the keys "major", "minor", and "patch" with int values and "prerelease" and
+// "meta" with string values, which are empty when the version has none, so every log
+// line carries the same fields.
+//
+// Example:
+//
+//	ver, _ := ParseVersion("1.2.3-rc.1")
+//	fmt.Println(ver.Fields()) // prints map[major:1 meta: minor:2 patch:3 prerelease:rc.1]
+func (s Semver) Fields() map[string]any {
+	return map[string]any{
+		"major":      s.Major,
+		"minor":      s.Minor,
+		"patch":      s.Patch,
+		"prerelease": s.Prerelease,
+		"meta":       s.Meta,
+	}
+}
+
+// CanonicalBytes returns a deterministic encoding of the fields of s that decide
+// precedence, for use as a content-addressed cache key or hash input. It is the canonical
+// string form without metadata and with leading zeros stripped from numeric prerelease
+// identifiers, so versions of equal precedence, such as 1.2.3+a and 1.2.3+b or
+// 1.0.0-rc.01 and 1.0.0-rc.1, encode to identical bytes and all other versions encode
+// differently.
+//
+// Example:
+//
+//	ver, _ := ParseVersion("1.2.3-rc.1+build.5")
+//	fmt.Printf("%s\n", ver.CanonicalBytes()) // prints 1.2.3-rc.1
+func (s Semver) CanonicalBytes() []byte {
+	k := s.key()
+	if k.Prerelease != "" {
+		ids := strings.Split(k.Prerelease, ".")
+		for i, id := range ids {
+			if isNumeric(id) {
+				if trimmed := strings.TrimLeft(id, "0"); trimmed != "" {
+					ids[i] = trimmed
+				} else {
+					ids[i] = "0"
+				}
+			}
+		}
+		k.Prerelease = strings.Join(ids, ".")
+	}
+	return []byte(k.String())
+}
+
+// Short returns an abbreviated form of s for display, dropping trailing zero components:
+// 1.2.0 becomes "1.2" and 1.0.0 becomes "1". Versions with a prerelease tag or metadata
+// are returned in full, as are versions with a non-zero patch. Use String for the
+// canonical form.
+//
+// Example:
+//
+//	ver, _ := ParseVersion("1.2.0")
+//	fmt.Println(ver.Short()) // prints 1.2
+func (s Semver) Short() string {
+	switch {
+	case s.Prerelease != "" || s.Meta != "" || s.Patch != 0:
+		return s.String()
+	case s.Minor != 0:
+		return fmt.Sprintf("%d.%d", s.Major, s.Minor)
+	}
+	return strconv.Itoa(s.Major)
+}
+
+// FormatWith renders s with sep between the major, minor, and patch components. The
+// prerelease tag and metadata are appended with their usual "-" and "+" delimiters, so
+// FormatWith(".") is the same as String.
+//
+// Example:
+//
+//	ver, _ := ParseVersion("1.2.3-rc.1")
+//	fmt.Println(ver.FormatWith("_")) // prints 1_2_3-rc.1
+func (s Semver) FormatWith(sep string) string {
+	var b strings.Builder
+	b.WriteString(strconv.Itoa(s.Major))
+	b.WriteString(sep)
+	b.WriteString(strconv.Itoa(s.Minor))
+	b.WriteString(sep)
+	b.WriteString(strconv.Itoa(s.Patch))
+	b.WriteString(s.suffix("-"))
+	return b.String()
+}
+
+// StringWithPreSep renders s like String, but with sep instead of "-" before the
+// prerelease tag, such as "~" for Debian-flavored display. Metadata keeps its "+"
+// delimiter. The result generally does not parse back with ParseVersion; use String for
+// the canonical form.
+//
+// Example:
+//
+//	ver, _ := ParseVersion("1.2.3-rc.1+build.5")
+//	fmt.Println(ver.StringWithPreSep("~")) // prints 1.2.3~rc.1+build.5
+func (s Semver) StringWithPreSep(sep string) string {
+	return fmt.Sprintf("%d.%d.%d", s.Major, s.Minor, s.Patch) + s.suffix(sep)
+}
+
+// Padded renders s with each of the major, minor, and patch components zero-padded to
+// width digits, so versions line up in table columns. Components wider than width are
+// not truncated. A width of zero or less adds no padding, giving the same result as
+// String. The prerelease tag and metadata are appended unchanged.
+//
+// Example:
+//
+//	ver, _ := ParseVersion("1.2.3")
+//	fmt.Println(ver.Padded(3)) // prints 001.002.003
+func (s Semver) Padded(width int) string {
+	if width < 0 {
+		width = 0
+	}
+	return fmt.Sprintf("%0*d.%0*d.%0*d", width, s.Major, width, s.Minor, width, s.Patch) + s.suffix("-")
+}
+
+// suffix returns the prerelease part of s after preSep and the "+" metadata part,
+// omitting empty ones.
+func (s Semver) suffix(preSep string) string {
+	var b strings.Builder
+	if s.Prerelease != "" {
+		b.WriteString(preSep)
+		b.WriteString(s.Prerelease)
+	}
+	if s.Meta != "" {
+		b.WriteString("+")
+		b.WriteString(s.Meta)
+	}
+	return b.String()
+}
+
+// Canonical normalizes a version string and returns its canonical form, as produced by
+// String. Prefixes such as "v" and surrounding text are dropped.
+//
+// If the version string cannot be parsed, the function returns an empty string and the
+// error.
+//
+// Example:
+//
+//	canonical, err := Canonical("v01.2.3-rc.1")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(canonical) // prints 1.2.3-rc.1
+func Canonical(v string) (string, error) {
+	ver, err := parse(v)
+	if err != nil {
+		return "", err
+	}
+	return ver.String(), nil
+}
+
+// CanonicalOptions adjusts how CanonicalWith renders a version. The zero value matches
+// Canonical.
+type CanonicalOptions struct {
+	// LowercaseMeta lowercases the metadata so that tools emitting it with inconsistent
+	// case produce the same key. Metadata does not affect precedence, so this never
+	// changes how versions compare. The core and prerelease tag are always kept as is,
+	// since case is significant in prerelease identifiers.
+	LowercaseMeta bool
+}
+
+// CanonicalWith normalizes a version string like Canonical, then applies opts.
+//
+// If the version string cannot be parsed, the function returns an empty string and the
+// error.
+//
+// Example:
+//
+//	canonical, err := CanonicalWith("1.2.3-RC.1+Build.ABC", CanonicalOptions{LowercaseMeta: true})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(canonical) // prints 1.2.3-RC.1+build.abc
+func CanonicalWith(v string, opts CanonicalOptions) (string, error) {
+	ver, err := parse(v)
+	if err != nil {
+		return "", err
+	}
+	if opts.LowercaseMeta {
+		ver.Meta = strings.ToLower(ver.Meta)
+	}
+	return ver.String(), nil
+}
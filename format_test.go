@@ -0,0 +1,234 @@
+package semver
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestFormatWith(t *testing.T) {
+	tests := []struct {
+		v        string
+		sep      string
+		expected string
+	}{
+		{"1.2.3", "_", "1_2_3"},
+		{"1.2.3", "-", "1-2-3"},
+		{"1.2.3", ".", "1.2.3"},
+		{"1.2.3-rc.1+build.5", "_", "1_2_3-rc.1+build.5"},
+		{"1.2.3-rc.1+build.5", ".", "1.2.3-rc.1+build.5"},
+	}
+
+	for _, test := range tests {
+		ver, err := ParseVersion(test.v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := ver.FormatWith(test.sep); got != test.expected {
+			t.Errorf("expected %s with separator %q to be %s but got %s", test.v, test.sep, test.expected, got)
+		}
+		if test.sep == "." && ver.String() != ver.FormatWith(test.sep) {
+			t.Errorf("expected the default separator to match String() for %s", test.v)
+		}
+	}
+}
+
+func TestStringWithPreSep(t *testing.T) {
+	tests := []struct {
+		v        string
+		sep      string
+		expected string
+	}{
+		{"1.2.3-rc.1", "~", "1.2.3~rc.1"},
+		{"1.2.3-rc.1+build.5", "~", "1.2.3~rc.1+build.5"},
+		{"1.2.3-rc.1+build.5", "-", "1.2.3-rc.1+build.5"},
+		{"1.2.3+build.5", "~", "1.2.3+build.5"},
+		{"1.2.3", "~", "1.2.3"},
+	}
+
+	for _, test := range tests {
+		ver, err := ParseVersion(test.v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := ver.StringWithPreSep(test.sep); got != test.expected {
+			t.Errorf("expected %s with prerelease separator %q to be %s but got %s", test.v, test.sep, test.expected, got)
+		}
+		if test.sep == "-" && ver.String() != ver.StringWithPreSep(test.sep) {
+			t.Errorf("expected the default separator to match String() for %s", test.v)
+		}
+	}
+}
+
+func TestPadded(t *testing.T) {
+	tests := []struct {
+		v        string
+		width    int
+		expected string
+	}{
+		{"1.2.3", 2, "01.02.03"},
+		{"1.2.3", 3, "001.002.003"},
+		{"1.20.300", 4, "0001.0020.0300"},
+		{"12345.2.3", 4, "12345.0002.0003"},
+		{"1.2.3-rc.1+build", 2, "01.02.03-rc.1+build"},
+		{"1.2.3", 0, "1.2.3"},
+		{"1.2.3-rc.1", -3, "1.2.3-rc.1"},
+	}
+
+	for _, test := range tests {
+		ver, err := ParseVersion(test.v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := ver.Padded(test.width); got != test.expected {
+			t.Errorf("expected %s padded to %d to be %s but got %s", test.v, test.width, test.expected, got)
+		}
+	}
+}
+
+func TestCanonical(t *testing.T) {
+	tests := []struct {
+		v        string
+		expected string
+	}{
+		{"v1.2.3", "1.2.3"},
+		{"v01.2.3-rc.1", "1.2.3-rc.1"},
+		{"release 1.2.3+build.5", "1.2.3+build.5"},
+	}
+
+	for _, test := range tests {
+		canonical, err := Canonical(test.v)
+		if err != nil {
+			t.Error(err)
+		}
+		if canonical != test.expected {
+			t.Errorf("expected %s to canonicalize to %s but got %s", test.v, test.expected, canonical)
+		}
+	}
+}
+
+func TestCanonicalWith(t *testing.T) {
+	tests := []struct {
+		v        string
+		opts     CanonicalOptions
+		expected string
+	}{
+		{"1.2.3+Build.ABC", CanonicalOptions{LowercaseMeta: true}, "1.2.3+build.abc"},
+		{"v1.2.3-RC.1+Build.ABC", CanonicalOptions{LowercaseMeta: true}, "1.2.3-RC.1+build.abc"},
+		{"1.2.3-Beta", CanonicalOptions{LowercaseMeta: true}, "1.2.3-Beta"},
+		{"1.2.3+Build.ABC", CanonicalOptions{}, "1.2.3+Build.ABC"},
+	}
+
+	for _, test := range tests {
+		canonical, err := CanonicalWith(test.v, test.opts)
+		if err != nil {
+			t.Error(err)
+		}
+		if canonical != test.expected {
+			t.Errorf("expected %s to canonicalize to %s with %+v but got %s", test.v, test.expected, test.opts, canonical)
+		}
+	}
+}
+
+func TestStringRoundTrip(t *testing.T) {
+	var _ fmt.Stringer = Semver{}
+
+	for _, v := range []string{"1.2.3", "1.2.3-alpha.1", "1.2.3-beta+exp.sha.5114f85", "0.0.0+20130313144700"} {
+		ver, err := ParseVersion(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ver.String() != v {
+			t.Errorf("expected %s to render as %s but got %s", v, v, ver)
+		}
+		if s := fmt.Sprintf("%s", ver); s != v {
+			t.Errorf("expected %%s of %s to be %s but got %s", v, v, s)
+		}
+
+		again, err := ParseVersion(ver.String())
+		if err != nil {
+			t.Error(err)
+		}
+		if again != ver {
+			t.Errorf("expected %s to round-trip to %+v but got %+v", v, ver, again)
+		}
+	}
+}
+
+func TestShort(t *testing.T) {
+	tests := []struct {
+		v        string
+		expected string
+	}{
+		{"1.2.0", "1.2"},
+		{"1.0.0", "1"},
+		{"1.2.3", "1.2.3"},
+		{"1.0.3", "1.0.3"},
+		{"0.0.0", "0"},
+		{"1.2.0-rc.1", "1.2.0-rc.1"},
+		{"1.2.0+build", "1.2.0+build"},
+	}
+
+	for _, test := range tests {
+		ver, err := ParseVersion(test.v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if short := ver.Short(); short != test.expected {
+			t.Errorf("expected %s to shorten to %s but got %s", test.v, test.expected, short)
+		}
+	}
+}
+
+func TestCanonicalBytes(t *testing.T) {
+	tests := []struct {
+		v1    string
+		v2    string
+		equal bool
+	}{
+		{"1.2.3+build.1", "1.2.3+build.2", true},
+		{"1.2.3-rc.1+a", "1.2.3-rc.1", true},
+		{"1.2.3", "1.2.4", false},
+		{"1.2.3-rc.1", "1.2.3", false},
+		{"1.2.3-rc.1", "1.2.3-rc.2", false},
+		{"1.0.0-rc.01", "1.0.0-rc.1", true},
+		{"1.0.0-00.x", "1.0.0-0.x+build", true},
+		{"1.0.0-rc.010", "1.0.0-rc.10", true},
+		{"1.0.0-rc.010", "1.0.0-rc.01", false},
+	}
+
+	for _, test := range tests {
+		ver1, err := ParseVersion(test.v1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ver2, err := ParseVersion(test.v2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if equal := bytes.Equal(ver1.CanonicalBytes(), ver2.CanonicalBytes()); equal != test.equal {
+			t.Errorf("expected bytes of %s and %s to be equal=%t but got %t", test.v1, test.v2, test.equal, equal)
+		}
+	}
+}
+
+func TestFields(t *testing.T) {
+	tests := []struct {
+		v        string
+		expected map[string]any
+	}{
+		{"1.2.3-rc.1+build.5", map[string]any{"major": 1, "minor": 2, "patch": 3, "prerelease": "rc.1", "meta": "build.5"}},
+		{"1.2.3", map[string]any{"major": 1, "minor": 2, "patch": 3, "prerelease": "", "meta": ""}},
+	}
+
+	for _, test := range tests {
+		ver, err := ParseVersion(test.v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fields := ver.Fields(); !reflect.DeepEqual(fields, test.expected) {
+			t.Errorf("expected fields of %s to be %v but got %v", test.v, test.expected, fields)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package semver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FuzzyVersion is a version whose patch component is a closed range, such as 1.2.3-5
+// for patches 3 through 5 of the 1.2 minor line.
+type FuzzyVersion struct {
+	Major     int
+	Minor     int
+	PatchLow  int
+	PatchHigh int
+}
+
+// ParseFuzzy takes a fuzzy version string of the form MAJOR.MINOR.LOW-HIGH and parses it
+// into a FuzzyVersion structure.
+//
+// In a fuzzy version the "-" after the patch always delimits the upper end of the patch
+// range, so the text after it must be a plain number; prerelease tags and metadata are not
+// supported. Use ParseVersion to read "-" as the start of a prerelease tag instead. A
+// version without "-" is a range of a single patch.
+//
+// If the string is malformed, or the upper end of the range is below the lower end, the
+// function returns an empty FuzzyVersion structure and an error.
+//
+// Example:
+//
+//	fuzzy, err := ParseFuzzy("1.2.3-5")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(fuzzy.Contains(Semver{Major: 1, Minor: 2, Patch: 4})) // prints true
+func ParseFuzzy(s string) (FuzzyVersion, error) {
+	core, high, hasRange := strings.Cut(s, "-")
+
+	major, minor, patch, err := splitVer(core)
+	if err != nil {
+		return FuzzyVersion{}, err
+	}
+
+	fuzzy := FuzzyVersion{Major: major, Minor: minor, PatchLow: patch, PatchHigh: patch}
+	if hasRange {
+		if !isNumeric(high) {
+			return FuzzyVersion{}, fmt.Errorf("invalid patch range end %q", high)
+		}
+		fuzzy.PatchHigh, err = strconv.Atoi(high)
+		if err != nil {
+			return FuzzyVersion{}, err
+		}
+	}
+
+	if fuzzy.PatchHigh < fuzzy.PatchLow {
+		return FuzzyVersion{}, fmt.Errorf("patch range %d-%d is empty", fuzzy.PatchLow, fuzzy.PatchHigh)
+	}
+
+	return fuzzy, nil
+}
+
+// Contains reports whether v is a release within the fuzzy range. Prerelease versions
+// are never contained, and metadata is ignored.
+func (f FuzzyVersion) Contains(v Semver) bool {
+	return v.Prerelease == "" &&
+		v.Major == f.Major &&
+		v.Minor == f.Minor &&
+		v.Patch >= f.PatchLow &&
+		v.Patch <= f.PatchHigh
+}
@@ -0,0 +1,50 @@
+package semver
+
+import "testing"
+
+func TestFuzzyVersion(t *testing.T) {
+	fuzzy, err := ParseFuzzy("1.2.3-5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := (FuzzyVersion{Major: 1, Minor: 2, PatchLow: 3, PatchHigh: 5}); fuzzy != expected {
+		t.Fatalf("expected %+v but got %+v", expected, fuzzy)
+	}
+
+	tests := []struct {
+		v        string
+		expected bool
+	}{
+		{"1.2.3", true},
+		{"1.2.4", true},
+		{"1.2.5+build", true},
+		{"1.2.2", false},
+		{"1.2.6", false},
+		{"1.3.4", false},
+		{"1.2.4-rc.1", false},
+	}
+
+	for _, test := range tests {
+		ver, err := ParseVersion(test.v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := fuzzy.Contains(ver); got != test.expected {
+			t.Errorf("expected 1.2.3-5 to contain %s=%t but got %t", test.v, test.expected, got)
+		}
+	}
+
+	single, err := ParseFuzzy("1.2.3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !single.Contains(Semver{Major: 1, Minor: 2, Patch: 3}) || single.Contains(Semver{Major: 1, Minor: 2, Patch: 4}) {
+		t.Errorf("expected 1.2.3 to contain only itself but got %+v", single)
+	}
+
+	for _, bad := range []string{"1.2.5-3", "1.2.3-rc.1", "1.2-5", "1.2.3-"} {
+		if _, err := ParseFuzzy(bad); err == nil {
+			t.Errorf("expected an error for %q", bad)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var goRe = regexp.MustCompile(`^(\d+)(?:\.(\d+))?(?:\.(\d+))?((?:alpha|beta|rc)\d+)?$`)
+
+// parseGoVersion parses a Go toolchain version such as "go1.21", "1.21.3", or "go1.22rc1"
+// into a Semver structure. Missing components are zero and a trailing release candidate
+// or beta suffix becomes the prerelease tag, so "go1.22rc1" parses as 1.22.0-rc1.
+func parseGoVersion(v string) (Semver, error) {
+	m := goRe.FindStringSubmatch(strings.TrimPrefix(v, "go"))
+	if m == nil {
+		return Semver{}, fmt.Errorf("invalid Go version %q", v)
+	}
+
+	vers := make([]int, 3)
+	for i, s := range m[1:4] {
+		if s == "" {
+			continue
+		}
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return Semver{}, err
+		}
+		vers[i] = n
+	}
+
+	return Semver{Major: vers[0], Minor: vers[1], Patch: vers[2], Prerelease: m[4]}, nil
+}
+
+// SatisfiesGoVersion reports whether a Go toolchain version satisfies a constraint.
+//
+// Go versions omit the patch component for the first release of a minor line and may
+// carry a "go" prefix, so "go1.21" is treated as 1.21.0 before checking it against the
+// constraint. Release candidates and betas are ranked like any other version rather than
+// excluded, so "go1.22rc1" satisfies ">=1.21" but not ">=1.22". The constraint uses the
+// syntax accepted by ParseConstraint.
+//
+// If the Go version or the constraint cannot be parsed, the function returns false and
+// the error.
+//
+// Example:
+//
+//	ok, err := SatisfiesGoVersion("go1.21", ">=1.20")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(ok) // prints true
+func SatisfiesGoVersion(goVersion, constraint string) (bool, error) {
+	ver, err := parseGoVersion(goVersion)
+	if err != nil {
+		return false, err
+	}
+	c, err := ParseConstraint(constraint)
+	if err != nil {
+		return false, err
+	}
+	c.AllowPrereleaseMatches = true
+	return c.Check(ver), nil
+}
+
+// ParseGo parses a Go module version such as "v1.2.3" or
+// "v0.0.0-20230101120000-abcdef123456". The leading "v" that Go requires is mandatory,
+// and the rest must be a valid version as ParseStrict defines it.
+//
+// If v lacks the "v" prefix or is not a valid version, the function returns an empty
+// Semver structure and an error.
+//
+// Example:
+//
+//	ver, err := ParseGo("v1.2.3")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(FormatGo(ver)) // prints v1.2.3
+func ParseGo(v string) (Semver, error) {
+	rest, ok := strings.CutPrefix(v, "v")
+	if !ok {
+		return Semver{}, fmt.Errorf("Go module version %q must start with \"v\"", v)
+	}
+	ver, err := ParseStrict(rest)
+	if err != nil {
+		return Semver{}, fmt.Errorf("invalid Go module version %q: %w", v, err)
+	}
+	return ver, nil
+}
+
+// FormatGo returns s as a Go module version, which is its canonical string with a
+// leading "v".
+func FormatGo(s Semver) string {
+	return "v" + s.String()
+}
+
+// IsPseudoVersion reports whether v is a Go pseudo-version, which the go command
+// generates for untagged commits. Pseudo-versions take one of three forms:
+//
+//	vX.0.0-yyyymmddhhmmss-abcdefabcdef          no earlier tag on the major version
+//	vX.Y.Z-pre.0.yyyymmddhhmmss-abcdefabcdef    the latest tag is vX.Y.Z-pre
+//	vX.Y.(Z+1)-0.yyyymmddhhmmss-abcdefabcdef    the latest tag is vX.Y.Z
+//
+// Pseudo-versions order correctly under Compare: above the tag they were derived from,
+// below the next release, and by commit time among each other.
+//
+// Example:
+//
+//	fmt.Println(IsPseudoVersion("v0.0.0-20230101120000-abcdef123456")) // prints true
+//	fmt.Println(IsPseudoVersion("v1.2.3-rc.1"))                        // prints false
+func IsPseudoVersion(v string) bool {
+	ver, err := ParseGo(v)
+	return err == nil && ver.IsPseudo()
+}
+
+// IsPseudo reports whether s has the shape of a Go pseudo-version, as described by
+// IsPseudoVersion.
+func (s Semver) IsPseudo() bool {
+	_, _, ok := s.Pseudo()
+	return ok
+}
+
+// Pseudo returns the commit time and revision identifier encoded in a Go pseudo-version,
+// such as 2023-01-01 12:00:00 UTC and "abcdef123456" for
+// v0.0.0-20230101120000-abcdef123456. If s is not a pseudo-version, the function returns
+// the zero time, an empty revision, and false.
+//
+// Example:
+//
+//	ver, _ := ParseGo("v1.2.4-0.20230101120000-abcdef123456")
+//	t, rev, ok := ver.Pseudo()
+//	fmt.Println(t.Format(time.DateOnly), rev, ok) // prints 2023-01-01 abcdef123456 true
+func (s Semver) Pseudo() (time.Time, string, bool) {
+	rest, last := "", s.Prerelease
+	if i := strings.LastIndexByte(s.Prerelease, '.'); i >= 0 {
+		rest, last = s.Prerelease[:i], s.Prerelease[i+1:]
+	}
+
+	switch {
+	case rest == "" && (s.Minor != 0 || s.Patch != 0):
+		return time.Time{}, "", false
+	case rest != "" && rest != "0" && !strings.HasSuffix(rest, ".0"):
+		return time.Time{}, "", false
+	}
+
+	stamp, rev, ok := strings.Cut(last, "-")
+	if !ok || len(stamp) != len("20060102150405") || !isNumeric(stamp) || rev == "" || strings.Contains(rev, "-") {
+		return time.Time{}, "", false
+	}
+	t, err := time.Parse("20060102150405", stamp)
+	if err != nil {
+		return time.Time{}, "", false
+	}
+	return t, rev, true
+}
@@ -0,0 +1,128 @@
+package semver
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSatisfiesGoVersion(t *testing.T) {
+	tests := []struct {
+		goVersion  string
+		constraint string
+		expected   bool
+	}{
+		{"go1.21", ">=1.20", true},
+		{"go1.21", ">=1.22", false},
+		{"1.21", ">=1.21.0", true},
+		{"go1.21.3", "~1.21", true},
+		{"go1.20.14", ">=1.21", false},
+		{"go1", "<1.1", true},
+		{"go1.22rc1", ">=1.21", true},
+		{"go1.22beta1", "^1.21", true},
+		{"go1.22rc1", ">=1.22", false},
+		{"go1.21rc2", "<1.21", true},
+	}
+
+	for _, test := range tests {
+		ok, err := SatisfiesGoVersion(test.goVersion, test.constraint)
+		if err != nil {
+			t.Error(err)
+		}
+		if ok != test.expected {
+			t.Errorf("expected %s to satisfy %q=%t but got %t", test.goVersion, test.constraint, test.expected, ok)
+		}
+	}
+
+	if ver, err := parseGoVersion("go1.22rc1"); err != nil || ver != (Semver{Major: 1, Minor: 22, Prerelease: "rc1"}) {
+		t.Errorf("expected go1.22rc1 to parse as 1.22.0-rc1 but got %+v (%v)", ver, err)
+	}
+
+	if _, err := SatisfiesGoVersion("go1.x", ">=1.20"); err == nil {
+		t.Error("expected an error for an invalid Go version")
+	}
+}
+
+func TestParseGo(t *testing.T) {
+	tests := []struct {
+		v        string
+		expected Semver
+	}{
+		{"v1.2.3", Semver{Major: 1, Minor: 2, Patch: 3}},
+		{"v2.0.0+incompatible", Semver{Major: 2, Meta: "incompatible"}},
+		{"v0.0.0-20230101120000-abcdef123456", Semver{Prerelease: "20230101120000-abcdef123456"}},
+	}
+
+	for _, test := range tests {
+		ver, err := ParseGo(test.v)
+		if err != nil {
+			t.Error(err)
+		}
+		if ver != test.expected {
+			t.Errorf("expected %s to parse as %+v but got %+v", test.v, test.expected, ver)
+		}
+		if result := FormatGo(ver); result != test.v {
+			t.Errorf("expected %s to format as itself but got %s", test.v, result)
+		}
+	}
+
+	for _, v := range []string{"1.2.3", "v1.2", "v01.2.3", "go1.21"} {
+		if _, err := ParseGo(v); err == nil {
+			t.Errorf("expected an error for %q", v)
+		}
+	}
+}
+
+func TestPseudoVersion(t *testing.T) {
+	stamp := time.Date(2023, time.January, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		v      string
+		pseudo bool
+	}{
+		{"v0.0.0-20230101120000-abcdef123456", true},
+		{"v2.0.0-20230101120000-abcdef123456+incompatible", true},
+		{"v1.2.4-0.20230101120000-abcdef123456", true},
+		{"v1.2.3-pre.0.20230101120000-abcdef123456", true},
+		{"v1.2.3-20230101120000-abcdef123456", false},
+		{"v1.2.4-1.20230101120000-abcdef123456", false},
+		{"v1.2.4-0.2023010112000-abcdef123456", false},
+		{"v1.2.4-0.20231301120000-abcdef123456", false},
+		{"v1.2.4-0.20230101120000", false},
+		{"v1.2.4-0.20230101120000-abc-def", false},
+		{"v1.2.3-rc.1", false},
+		{"v1.2.3", false},
+		{"0.0.0-20230101120000-abcdef123456", false},
+	}
+
+	for _, test := range tests {
+		if result := IsPseudoVersion(test.v); result != test.pseudo {
+			t.Errorf("expected IsPseudoVersion(%s)=%t but got %t", test.v, test.pseudo, result)
+		}
+		if !test.pseudo {
+			continue
+		}
+		ver, _ := ParseGo(test.v)
+		ts, rev, ok := ver.Pseudo()
+		if !ok || !ts.Equal(stamp) || rev != "abcdef123456" {
+			t.Errorf("expected %s to encode %v and abcdef123456 but got %v, %q, %t", test.v, stamp, ts, rev, ok)
+		}
+	}
+
+	ordered := []string{
+		"v1.2.3",
+		"v1.2.4-0.20230101120000-abcdef123456",
+		"v1.2.4-0.20230202120000-123456abcdef",
+		"v1.2.4",
+		"v1.3.0-pre",
+		"v1.3.0-pre.0.20230101120000-abcdef123456",
+		"v1.3.0-pre.1",
+	}
+	for i := 1; i < len(ordered); i++ {
+		result, err := Compare(ordered[i-1], ordered[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result != -1 {
+			t.Errorf("expected %s to rank below %s", ordered[i-1], ordered[i])
+		}
+	}
+}
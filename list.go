@@ -0,0 +1,527 @@
+package semver
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// PrereleaseStages returns the distinct prerelease stages found in a list of versions.
+//
+// A stage is the PrereleaseBase of a version, so "1.0.0-rc.1" and "1.1.0-rc.4" both
+// contribute "rc". Stable releases are skipped. The result is sorted lexicographically.
+//
+// If any version cannot be parsed, the function returns nil and the error.
+//
+// Example:
+//
+//	stages, err := PrereleaseStages([]string{"1.0.0-rc.1", "1.0.0", "1.1.0-alpha"})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(stages) // prints [alpha rc]
+func PrereleaseStages(versions []string) ([]string, error) {
+	vers, err := parseAll(versions)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	stages := []string{}
+	for _, ver := range vers {
+		base := ver.PrereleaseBase()
+		if base == "" || seen[base] {
+			continue
+		}
+		seen[base] = true
+		stages = append(stages, base)
+	}
+
+	sort.Strings(stages)
+	return stages, nil
+}
+
+// ConsistentPrereleaseScheme reports whether every prerelease version in a list uses the
+// same stage vocabulary, and returns the versions that deviate from it otherwise. This
+// catches typos such as "1.2.0-cr.1" in a list of release candidates.
+//
+// The stage of a version is its PrereleaseBase. The well-known stages alpha, beta, and
+// rc form one vocabulary; any other stage, such as "pre" or "preview", is a vocabulary of
+// its own. The scheme of the list is the vocabulary used by the most prerelease versions,
+// with ties going to the one seen first, so a list using only "pre" is as consistent as
+// one moving from alpha to rc. Stable releases are ignored. Offenders are returned as
+// given, in their original order.
+//
+// If any version cannot be parsed, the function returns false, nil, and the error.
+//
+// Example:
+//
+//	ok, offenders, err := ConsistentPrereleaseScheme([]string{"1.2.0-rc.1", "1.2.0-cr.2"})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(ok, offenders) // prints false [1.2.0-cr.2]
+func ConsistentPrereleaseScheme(versions []string) (bool, []string, error) {
+	vers, err := parseAll(versions)
+	if err != nil {
+		return false, nil, err
+	}
+
+	vocabulary := func(ver Semver) string {
+		if stage := ver.PrereleaseBase(); stageRank(stage) < 0 {
+			return stage
+		}
+		return strings.Join(stages, "/")
+	}
+
+	counts := make(map[string]int)
+	scheme := ""
+	for _, ver := range vers {
+		if ver.Prerelease == "" {
+			continue
+		}
+		vocab := vocabulary(ver)
+		counts[vocab]++
+		if scheme == "" || counts[vocab] > counts[scheme] {
+			scheme = vocab
+		}
+	}
+
+	offenders := []string{}
+	for i, ver := range vers {
+		if ver.Prerelease != "" && vocabulary(ver) != scheme {
+			offenders = append(offenders, versions[i])
+		}
+	}
+
+	return len(offenders) == 0, offenders, nil
+}
+
+// PrereleaseCollisions finds release cores that have more than one prerelease variant.
+//
+// The result maps each such core, written as MAJOR.MINOR.PATCH, to its prerelease
+// versions sorted in ascending order of precedence. Cores with a single prerelease, or
+// with only stable releases, are omitted. Versions differing only in metadata count as
+// one variant.
+//
+// If any version cannot be parsed, the function returns nil and the error.
+//
+// Example:
+//
+//	collisions, err := PrereleaseCollisions([]string{"1.2.0-rc.1", "1.2.0-rc.2", "1.3.0-rc.1"})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(collisions) // prints map[1.2.0:[1.2.0-rc.1 1.2.0-rc.2]]
+func PrereleaseCollisions(versions []string) (map[string][]string, error) {
+	vers, err := parseAll(versions)
+	if err != nil {
+		return nil, err
+	}
+
+	type group struct {
+		raw  []string
+		vers []Semver
+		seen map[string]bool
+	}
+	groups := make(map[string]*group)
+	for i, ver := range vers {
+		if ver.Prerelease == "" {
+			continue
+		}
+		core := ver.core().String()
+		g, ok := groups[core]
+		if !ok {
+			g = &group{seen: make(map[string]bool)}
+			groups[core] = g
+		}
+		if g.seen[ver.Prerelease] {
+			continue
+		}
+		g.seen[ver.Prerelease] = true
+		g.raw = append(g.raw, versions[i])
+		g.vers = append(g.vers, ver)
+	}
+
+	collisions := make(map[string][]string)
+	for core, g := range groups {
+		if len(g.raw) < 2 {
+			continue
+		}
+		sort.Stable(byVersion{g.raw, g.vers})
+		collisions[core] = g.raw
+	}
+
+	return collisions, nil
+}
+
+// MissingPatches finds the patch releases absent from each minor line of a version list.
+//
+// Versions are grouped by major and minor, and every patch number between the lowest
+// and highest patch present in a group that has no release of its own is reported.
+// Prerelease versions do not count as releases. The result is sorted in ascending order.
+//
+// If any version cannot be parsed, the function returns nil and the error.
+//
+// Example:
+//
+//	missing, err := MissingPatches([]string{"1.0.0", "1.0.3"})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(missing) // prints [1.0.1 1.0.2]
+func MissingPatches(versions []string) ([]string, error) {
+	vers, err := parseAll(versions)
+	if err != nil {
+		return nil, err
+	}
+
+	released := make(map[Semver]bool)
+	lines := make(map[Semver][2]int)
+	for _, ver := range vers {
+		if ver.Prerelease != "" {
+			continue
+		}
+		released[ver.core()] = true
+
+		line := Semver{Major: ver.Major, Minor: ver.Minor}
+		bounds, ok := lines[line]
+		if !ok {
+			bounds = [2]int{ver.Patch, ver.Patch}
+		}
+		if ver.Patch < bounds[0] {
+			bounds[0] = ver.Patch
+		}
+		if ver.Patch > bounds[1] {
+			bounds[1] = ver.Patch
+		}
+		lines[line] = bounds
+	}
+
+	missing := []Semver{}
+	for line, bounds := range lines {
+		for patch := bounds[0] + 1; patch < bounds[1]; patch++ {
+			ver := Semver{Major: line.Major, Minor: line.Minor, Patch: patch}
+			if !released[ver] {
+				missing = append(missing, ver)
+			}
+		}
+	}
+
+	sort.Slice(missing, func(i, j int) bool {
+		return compareCore(missing[i], missing[j]) < 0
+	})
+
+	result := make([]string, len(missing))
+	for i, ver := range missing {
+		result[i] = ver.String()
+	}
+	return result, nil
+}
+
+// Difference returns the versions in newer that are not in older, sorted in ascending
+// order of precedence.
+//
+// Versions are matched by precedence, so prefixes and metadata are ignored: "v1.2.3+b"
+// in newer is considered present when older holds "1.2.3". When newer holds several
+// spellings of the same version, only the first is returned.
+//
+// If any version cannot be parsed, the function returns nil and the error.
+//
+// Example:
+//
+//	added, err := Difference([]string{"1.0.0", "1.1.0", "1.2.0"}, []string{"1.0.0"})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(added) // prints [1.1.0 1.2.0]
+func Difference(newer, older []string) ([]string, error) {
+	newVers, err := parseAll(newer)
+	if err != nil {
+		return nil, err
+	}
+	oldVers, err := parseAll(older)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[Semver]bool)
+	for _, ver := range oldVers {
+		seen[ver.key()] = true
+	}
+
+	raw, vers := []string{}, []Semver{}
+	for i, ver := range newVers {
+		if seen[ver.key()] {
+			continue
+		}
+		seen[ver.key()] = true
+		raw = append(raw, newer[i])
+		vers = append(vers, ver)
+	}
+
+	sort.Stable(byVersion{raw, vers})
+	return raw, nil
+}
+
+// GroupByMinor groups version strings by their minor line, keyed as "MAJOR.MINOR". Each
+// group is sorted in ascending order of precedence.
+//
+// If any version cannot be parsed, the function returns nil and the error.
+//
+// Example:
+//
+//	groups, err := GroupByMinor([]string{"1.2.1", "1.3.0", "1.2.0"})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(groups["1.2"]) // prints [1.2.0 1.2.1]
+func GroupByMinor(versions []string) (map[string][]string, error) {
+	vers, err := parseAll(versions)
+	if err != nil {
+		return nil, err
+	}
+
+	raw := make(map[string][]string)
+	parsed := make(map[string][]Semver)
+	for i, ver := range vers {
+		line := ver.Train()
+		raw[line] = append(raw[line], versions[i])
+		parsed[line] = append(parsed[line], ver)
+	}
+
+	for line := range raw {
+		sort.Stable(byVersion{raw[line], parsed[line]})
+	}
+	return raw, nil
+}
+
+// Train returns the label of the release train s belongs to, which is its minor line
+// written as "MAJOR.MINOR". GroupByMinor uses the same labels as keys.
+//
+// Example:
+//
+//	ver, _ := ParseVersion("1.2.3")
+//	fmt.Println(ver.Train()) // prints 1.2
+func (s Semver) Train() string {
+	return fmt.Sprintf("%d.%d", s.Major, s.Minor)
+}
+
+// TrainMembers returns the versions on the given release train, such as "1.2", sorted in
+// ascending order of precedence.
+//
+// If train is not a "MAJOR.MINOR" label or any version cannot be parsed, the function
+// returns nil and an error.
+//
+// Example:
+//
+//	members, err := TrainMembers([]string{"1.2.1", "1.3.0", "1.2.0"}, "1.2")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(members) // prints [1.2.0 1.2.1]
+func TrainMembers(versions []string, train string) ([]string, error) {
+	label, parts, err := parsePartial(train)
+	if err != nil {
+		return nil, fmt.Errorf("invalid train %q: %w", train, err)
+	}
+	if parts != 2 {
+		return nil, fmt.Errorf("invalid train %q: expected MAJOR.MINOR", train)
+	}
+
+	groups, err := GroupByMinor(versions)
+	if err != nil {
+		return nil, err
+	}
+	if members, ok := groups[label.Train()]; ok {
+		return members, nil
+	}
+	return []string{}, nil
+}
+
+// LatestPerChannel buckets versions into release channels with classify, such as
+// "stable", "beta", and "nightly", and returns the highest version in each channel. A
+// version for which classify returns an empty string belongs to no channel and is
+// skipped. Among versions of equal precedence the first one wins.
+//
+// If any version cannot be parsed, the function returns nil and the error.
+//
+// Example:
+//
+//	latest, err := LatestPerChannel([]string{"1.2.0", "1.3.0-rc.1", "1.1.0"}, func(v Semver) string {
+//	    if v.Prerelease == "" {
+//	        return "stable"
+//	    }
+//	    return "preview"
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(latest["stable"], latest["preview"]) // prints 1.2.0 1.3.0-rc.1
+func LatestPerChannel(versions []string, classify func(Semver) string) (map[string]string, error) {
+	vers, err := parseAll(versions)
+	if err != nil {
+		return nil, err
+	}
+
+	latest := make(map[string]string)
+	best := make(map[string]Semver)
+	for i, ver := range vers {
+		channel := classify(ver)
+		if channel == "" {
+			continue
+		}
+		if cur, ok := best[channel]; !ok || compare(ver, cur) > 0 {
+			latest[channel], best[channel] = versions[i], ver
+		}
+	}
+	return latest, nil
+}
+
+// OldestSupported returns the lowest release among the newest keepMinors minor lines,
+// implementing a "support the last N minor versions" policy.
+//
+// Prerelease versions are ignored, both as candidates and when deciding which minor
+// lines exist. When fewer than keepMinors lines exist, all of them are supported.
+//
+// If keepMinors is not positive, no releases are given, or any version cannot be parsed,
+// the function returns an empty string and an error.
+//
+// Example:
+//
+//	oldest, err := OldestSupported([]string{"1.0.0", "1.1.0", "1.1.1", "1.2.0"}, 2)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(oldest) // prints 1.1.0
+func OldestSupported(versions []string, keepMinors int) (string, error) {
+	lines, groups, err := supportedLines(versions, keepMinors)
+	if err != nil {
+		return "", err
+	}
+
+	// groups are sorted ascending, so the first entry of the oldest line is the answer
+	return groups[lines[len(lines)-1].Train()][0], nil
+}
+
+// InSupportWindow reports whether the minor line of v is among the newest keepMinors
+// minor lines present in all, following the same rules as OldestSupported. Any version
+// on a supported line is inside the window, including prereleases of it.
+//
+// If keepMinors is not positive, all holds no releases, or any version cannot be parsed,
+// the function returns false and an error.
+//
+// Example:
+//
+//	ok, err := InSupportWindow("1.0.3", []string{"1.0.0", "1.1.0", "1.2.0"}, 2)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(ok) // prints false
+func InSupportWindow(v string, all []string, keepMinors int) (bool, error) {
+	ver, err := parse(v)
+	if err != nil {
+		return false, err
+	}
+	lines, _, err := supportedLines(all, keepMinors)
+	if err != nil {
+		return false, err
+	}
+
+	for _, line := range lines {
+		if line.Major == ver.Major && line.Minor == ver.Minor {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// supportedLines returns the newest keepMinors minor lines among the releases in
+// versions, newest first, together with the releases grouped by minor line.
+func supportedLines(versions []string, keepMinors int) ([]Semver, map[string][]string, error) {
+	if keepMinors <= 0 {
+		return nil, nil, fmt.Errorf("keepMinors must be positive, got %d", keepMinors)
+	}
+	vers, err := parseAll(versions)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	releases := []string{}
+	for i, ver := range vers {
+		if ver.Prerelease == "" {
+			releases = append(releases, versions[i])
+		}
+	}
+	if len(releases) == 0 {
+		return nil, nil, fmt.Errorf("no releases given")
+	}
+
+	groups, err := GroupByMinor(releases)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	lines := make([]Semver, 0, len(groups))
+	for _, group := range groups {
+		ver, _ := parse(group[0])
+		lines = append(lines, Semver{Major: ver.Major, Minor: ver.Minor})
+	}
+	sort.Slice(lines, func(i, j int) bool {
+		return compareCore(lines[i], lines[j]) > 0
+	})
+	if len(lines) > keepMinors {
+		lines = lines[:keepMinors]
+	}
+
+	return lines, groups, nil
+}
+
+// CommonAncestor returns the compatibility baseline of a set of versions: the greatest
+// version that is less than or equal to every input, which is the input with the lowest
+// precedence. The original string is returned.
+//
+// If the list is empty or any version cannot be parsed, the function returns an empty
+// string and an error.
+//
+// Example:
+//
+//	base, err := CommonAncestor([]string{"1.4.0", "1.2.5", "1.3.0"})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(base) // prints 1.2.5
+func CommonAncestor(versions []string) (string, error) {
+	return Min(versions)
+}
+
+// GreatestCommonMajor reports whether every version in the list shares the same major
+// version and, if so, which one. When the versions span several majors, the function
+// returns 0 and false.
+//
+// If the list is empty or any version cannot be parsed, the function returns 0, false,
+// and an error.
+//
+// Example:
+//
+//	major, ok, err := GreatestCommonMajor([]string{"1.4.0", "1.2.5", "v1.3.0"})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(major, ok) // prints 1 true
+func GreatestCommonMajor(versions []string) (int, bool, error) {
+	if len(versions) == 0 {
+		return 0, false, fmt.Errorf("no versions given")
+	}
+	vers, err := parseAll(versions)
+	if err != nil {
+		return 0, false, err
+	}
+
+	for _, ver := range vers[1:] {
+		if ver.Major != vers[0].Major {
+			return 0, false, nil
+		}
+	}
+	return vers[0].Major, true, nil
+}
@@ -0,0 +1,289 @@
+package semver
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPrereleaseStages(t *testing.T) {
+	versions := []string{"1.0.0-rc.1", "1.0.0", "1.1.0-beta.2", "1.1.0-alpha", "1.2.0-rc.3", "1.1.0-beta.1", "2.0.0+build"}
+
+	stages, err := PrereleaseStages(versions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"alpha", "beta", "rc"}; !reflect.DeepEqual(stages, expected) {
+		t.Errorf("expected %v but got %v", expected, stages)
+	}
+
+	stages, err = PrereleaseStages([]string{"1.0.0", "2.0.0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stages) != 0 {
+		t.Errorf("expected no stages for stable versions but got %v", stages)
+	}
+}
+
+func TestPrereleaseCollisions(t *testing.T) {
+	versions := []string{"1.2.0-rc.2", "1.2.0", "1.2.0-rc.1", "1.3.0-rc.1", "1.4.0", "1.3.0-rc.1+build"}
+
+	collisions, err := PrereleaseCollisions(versions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string][]string{"1.2.0": {"1.2.0-rc.1", "1.2.0-rc.2"}}
+	if !reflect.DeepEqual(collisions, expected) {
+		t.Errorf("expected %v but got %v", expected, collisions)
+	}
+}
+
+func TestMissingPatches(t *testing.T) {
+	tests := []struct {
+		versions []string
+		expected []string
+	}{
+		{[]string{"1.0.0", "1.0.3"}, []string{"1.0.1", "1.0.2"}},
+		{[]string{"1.0.3", "1.0.0", "1.0.2", "1.1.1", "1.1.4", "2.0.0"}, []string{"1.0.1", "1.1.2", "1.1.3"}},
+		{[]string{"1.0.0", "1.0.1-rc.1", "1.0.2"}, []string{"1.0.1"}},
+		{[]string{"1.0.0", "1.0.1"}, []string{}},
+	}
+
+	for _, test := range tests {
+		missing, err := MissingPatches(test.versions)
+		if err != nil {
+			t.Error(err)
+		}
+		if !reflect.DeepEqual(missing, test.expected) {
+			t.Errorf("expected missing patches of %v to be %v but got %v", test.versions, test.expected, missing)
+		}
+	}
+}
+
+func TestDifference(t *testing.T) {
+	newer := []string{"1.2.0", "v1.0.0", "1.1.0-rc.1", "1.0.1+build", "1.2.0+again"}
+	older := []string{"1.0.0", "1.0.1"}
+
+	added, err := Difference(newer, older)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"1.1.0-rc.1", "1.2.0"}; !reflect.DeepEqual(added, expected) {
+		t.Errorf("expected %v but got %v", expected, added)
+	}
+
+	if _, err := Difference([]string{"nope"}, older); err == nil {
+		t.Error("expected an error for an invalid version")
+	}
+}
+
+func TestGroupByMinor(t *testing.T) {
+	groups, err := GroupByMinor([]string{"1.2.1", "1.3.0", "1.2.0", "v1.2.0-rc.1", "2.2.0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string][]string{
+		"1.2": {"v1.2.0-rc.1", "1.2.0", "1.2.1"},
+		"1.3": {"1.3.0"},
+		"2.2": {"2.2.0"},
+	}
+	if !reflect.DeepEqual(groups, expected) {
+		t.Errorf("expected %v but got %v", expected, groups)
+	}
+}
+
+func TestLatestPerChannel(t *testing.T) {
+	classify := func(v Semver) string {
+		if v.Prerelease == "" {
+			return "stable"
+		}
+		stage := strings.Split(v.Prerelease, ".")[0]
+		if stage == "rc" || stage == "alpha" {
+			return stage
+		}
+		return ""
+	}
+
+	versions := []string{"1.2.0", "1.3.0-rc.1", "1.3.0-alpha.4", "1.1.9", "1.3.0-rc.2", "1.4.0-alpha.1", "1.3.0-beta.1", "v1.2.0"}
+	latest, err := LatestPerChannel(versions, classify)
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := map[string]string{"stable": "1.2.0", "rc": "1.3.0-rc.2", "alpha": "1.4.0-alpha.1"}
+	if !reflect.DeepEqual(latest, expected) {
+		t.Errorf("expected %v but got %v", expected, latest)
+	}
+
+	if _, err := LatestPerChannel([]string{"1.0.0", "nope"}, classify); err == nil {
+		t.Error("expected an error for an invalid version")
+	}
+}
+
+func TestOldestSupported(t *testing.T) {
+	versions := []string{"1.0.0", "1.0.1", "1.1.0", "1.1.1", "1.2.0-rc.1", "1.2.0", "1.2.3", "1.3.0-beta.1"}
+
+	tests := []struct {
+		keep     int
+		expected string
+	}{
+		{1, "1.2.0"},
+		{2, "1.1.0"},
+		{3, "1.0.0"},
+		{10, "1.0.0"},
+	}
+
+	for _, test := range tests {
+		oldest, err := OldestSupported(versions, test.keep)
+		if err != nil {
+			t.Error(err)
+		}
+		if oldest != test.expected {
+			t.Errorf("expected oldest supported with keepMinors=%d to be %s but got %s", test.keep, test.expected, oldest)
+		}
+	}
+
+	if _, err := OldestSupported(versions, 0); err == nil {
+		t.Error("expected an error for keepMinors=0")
+	}
+	if _, err := OldestSupported([]string{"1.0.0-rc.1"}, 1); err == nil {
+		t.Error("expected an error when no releases are given")
+	}
+}
+
+func TestCommonAncestor(t *testing.T) {
+	base, err := CommonAncestor([]string{"1.4.0", "1.2.5", "1.3.0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if base != "1.2.5" {
+		t.Errorf("expected 1.2.5 but got %s", base)
+	}
+
+	if _, err := CommonAncestor(nil); err == nil {
+		t.Error("expected an error for an empty list")
+	}
+}
+
+func TestGreatestCommonMajor(t *testing.T) {
+	tests := []struct {
+		versions []string
+		major    int
+		ok       bool
+	}{
+		{[]string{"1.4.0", "1.2.5", "v1.3.0"}, 1, true},
+		{[]string{"0.2.0"}, 0, true},
+		{[]string{"1.4.0", "2.0.0-rc.1", "1.3.0"}, 0, false},
+	}
+
+	for _, test := range tests {
+		major, ok, err := GreatestCommonMajor(test.versions)
+		if err != nil {
+			t.Error(err)
+		}
+		if major != test.major || ok != test.ok {
+			t.Errorf("expected %v to give %d %v but got %d %v", test.versions, test.major, test.ok, major, ok)
+		}
+	}
+
+	if _, _, err := GreatestCommonMajor([]string{}); err == nil {
+		t.Error("expected an error for an empty list")
+	}
+}
+
+func TestConsistentPrereleaseScheme(t *testing.T) {
+	tests := []struct {
+		versions  []string
+		ok        bool
+		offenders []string
+	}{
+		{[]string{"1.2.0-rc.1", "1.2.0-cr.2", "1.2.0", "1.3.0-rc.1"}, false, []string{"1.2.0-cr.2"}},
+		{[]string{"1.2.0-alpha.1", "1.2.0-beta", "1.2.0-rc.3", "1.2.0+build"}, true, []string{}},
+		{[]string{"1.2.0", "1.3.0"}, true, []string{}},
+		{[]string{"1.2.0-preview.1", "1.2.0-rc.1.hotfix"}, false, []string{"1.2.0-rc.1.hotfix"}},
+		{[]string{"1.0.0-pre.1", "1.1.0-pre.2"}, true, []string{}},
+		{[]string{"1.0.0-pre.1", "1.1.0-rc.1", "1.1.0-pre.2"}, false, []string{"1.1.0-rc.1"}},
+		{[]string{"1.0.0-alpha.1", "1.1.0-rc.2", "1.1.0-pre.1"}, false, []string{"1.1.0-pre.1"}},
+	}
+
+	for _, test := range tests {
+		ok, offenders, err := ConsistentPrereleaseScheme(test.versions)
+		if err != nil {
+			t.Error(err)
+		}
+		if ok != test.ok || !reflect.DeepEqual(offenders, test.offenders) {
+			t.Errorf("expected %v to give %v %v but got %v %v", test.versions, test.ok, test.offenders, ok, offenders)
+		}
+	}
+
+	if _, _, err := ConsistentPrereleaseScheme([]string{"latest"}); err == nil {
+		t.Error("expected an error for an invalid version")
+	}
+}
+
+func TestInSupportWindow(t *testing.T) {
+	all := []string{"1.0.0", "1.0.1", "1.1.0", "1.2.0", "1.2.1", "1.3.0-rc.1"}
+
+	tests := []struct {
+		v        string
+		expected bool
+	}{
+		{"1.0.1", false},
+		{"1.1.0", true},
+		{"1.1.5", true},
+		{"1.2.1", true},
+		{"1.3.0-rc.1", false},
+		{"2.0.0", false},
+	}
+
+	for _, test := range tests {
+		ok, err := InSupportWindow(test.v, all, 2)
+		if err != nil {
+			t.Error(err)
+		}
+		if ok != test.expected {
+			t.Errorf("expected %s in a 2-minor window to be %v but got %v", test.v, test.expected, ok)
+		}
+	}
+
+	if _, err := InSupportWindow("1.2.0", all, 0); err == nil {
+		t.Error("expected an error for a non-positive window")
+	}
+}
+
+func TestTrain(t *testing.T) {
+	ver, err := ParseVersion("1.2.3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if train := ver.Train(); train != "1.2" {
+		t.Errorf("expected the train of 1.2.3 to be 1.2 but got %s", train)
+	}
+
+	versions := []string{"1.2.3", "1.3.0", "v1.2.0", "1.2.1-rc.1", "2.2.0", "1.20.0"}
+	tests := []struct {
+		train    string
+		expected []string
+	}{
+		{"1.2", []string{"v1.2.0", "1.2.1-rc.1", "1.2.3"}},
+		{"1.3", []string{"1.3.0"}},
+		{"3.0", []string{}},
+	}
+
+	for _, test := range tests {
+		members, err := TrainMembers(versions, test.train)
+		if err != nil {
+			t.Error(err)
+		}
+		if !reflect.DeepEqual(members, test.expected) {
+			t.Errorf("expected members of train %s to be %v but got %v", test.train, test.expected, members)
+		}
+	}
+
+	for _, bad := range []string{"1", "1.2.3", "one.two", ""} {
+		if _, err := TrainMembers(versions, bad); err == nil {
+			t.Errorf("expected an error for train %q", bad)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package semver
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SetMeta returns s with its metadata replaced by meta. An empty meta removes the
+// metadata. Unlike prerelease identifiers, numeric metadata identifiers may have leading
+// zeros, as in "1.2.3+001".
+//
+// If meta holds an empty identifier or a character other than ASCII letters, digits, and
+// hyphens, the function returns an empty Semver structure and an error.
+//
+// Example:
+//
+//	ver, _ := ParseVersion("1.2.3")
+//	built, err := ver.SetMeta("build.42")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(built) // prints 1.2.3+build.42
+func (s Semver) SetMeta(meta string) (Semver, error) {
+	if err := validIdentifiers(meta, false); err != nil {
+		return Semver{}, fmt.Errorf("invalid metadata %q: %w", meta, err)
+	}
+	s.Meta = meta
+	return s, nil
+}
+
+// ReleaseDate returns the release date encoded in the metadata of s.
+//
+// The function looks for a dot-separated metadata identifier shaped like YYYY-MM-DD,
+// such as the "2023-05-01" in "1.2.3+2023-05-01", and parses it as a UTC date. The
+// first matching identifier wins. If no identifier holds a valid date, the function
+// returns the zero time and false.
+//
+// Example:
+//
+//	ver, _ := ParseVersion("1.2.3+2023-05-01")
+//	date, ok := ver.ReleaseDate()
+//	fmt.Println(date.Format("Jan 2, 2006"), ok) // prints May 1, 2023 true
+func (s Semver) ReleaseDate() (time.Time, bool) {
+	if s.Meta == "" {
+		return time.Time{}, false
+	}
+
+	for _, ident := range strings.Split(s.Meta, ".") {
+		if len(ident) != len("2006-01-02") {
+			continue
+		}
+		if date, err := time.Parse("2006-01-02", ident); err == nil {
+			return date, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// metaNumber finds the first metadata identifier made of prefix followed by digits and
+// returns its number. For example, metaNumber("build.r42", "r") returns 42. An empty
+// prefix matches the first purely numeric identifier.
+func metaNumber(meta, prefix string) (int, bool) {
+	if meta == "" {
+		return 0, false
+	}
+	for _, ident := range strings.Split(meta, ".") {
+		digits := strings.TrimPrefix(ident, prefix)
+		if (prefix != "" && len(digits) == len(ident)) || !isNumeric(digits) {
+			continue
+		}
+		if n, err := strconv.Atoi(digits); err == nil {
+			return n, true
+		}
+	}
+	return 0, false
+}
+
+// CheckBuildMonotonic finds versions whose build number is lower than that of a version
+// with lower precedence, which points at a CI misconfiguration.
+//
+// The build number is read from the first metadata identifier made of metaPrefix
+// followed by digits, such as "b" for "1.2.0+b1042"; an empty metaPrefix reads the first
+// purely numeric identifier. Versions without a build number are ignored, as are
+// differences between builds of equal precedence. The offenders are returned as given,
+// sorted in ascending order of precedence.
+//
+// If any version cannot be parsed, the function returns nil and the error.
+//
+// Example:
+//
+//	bad, err := CheckBuildMonotonic([]string{"1.0.0+b10", "1.1.0+b7", "1.2.0+b12"}, "b")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(bad) // prints [1.1.0+b7]
+func CheckBuildMonotonic(versions []string, metaPrefix string) ([]string, error) {
+	vers, err := parseAll(versions)
+	if err != nil {
+		return nil, err
+	}
+
+	type build struct {
+		raw string
+		ver Semver
+		n   int
+	}
+	builds := []build{}
+	for i, ver := range vers {
+		if n, ok := metaNumber(ver.Meta, metaPrefix); ok {
+			builds = append(builds, build{versions[i], ver, n})
+		}
+	}
+	sort.SliceStable(builds, func(i, j int) bool {
+		return compare(builds[i].ver, builds[j].ver) < 0
+	})
+
+	// highest is the largest build number among lower precedence groups, and
+	// groupHighest the largest within the current group; build numbers are never negative
+	offenders := []string{}
+	highest, groupHighest := -1, -1
+	for i, b := range builds {
+		if i > 0 && compare(builds[i-1].ver, b.ver) != 0 {
+			if groupHighest > highest {
+				highest = groupHighest
+			}
+			groupHighest = -1
+		}
+
+		if b.n < highest {
+			offenders = append(offenders, b.raw)
+		}
+		if b.n > groupHighest {
+			groupHighest = b.n
+		}
+	}
+
+	return offenders, nil
+}
@@ -0,0 +1,87 @@
+package semver
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestReleaseDate(t *testing.T) {
+	tests := []struct {
+		v        string
+		expected time.Time
+		ok       bool
+	}{
+		{"1.2.3+2023-05-01", time.Date(2023, time.May, 1, 0, 0, 0, 0, time.UTC), true},
+		{"1.2.3-rc.1+build.2023-12-31", time.Date(2023, time.December, 31, 0, 0, 0, 0, time.UTC), true},
+		{"1.2.3+build", time.Time{}, false},
+		{"1.2.3+2023-13-01", time.Time{}, false},
+		{"1.2.3", time.Time{}, false},
+	}
+
+	for _, test := range tests {
+		ver, err := ParseVersion(test.v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		date, ok := ver.ReleaseDate()
+		if ok != test.ok || !date.Equal(test.expected) {
+			t.Errorf("expected %s to have release date %v (%t) but got %v (%t)", test.v, test.expected, test.ok, date, ok)
+		}
+	}
+}
+
+func TestCheckBuildMonotonic(t *testing.T) {
+	tests := []struct {
+		versions []string
+		prefix   string
+		expected []string
+	}{
+		{[]string{"1.0.0+b10", "1.1.0+b7", "1.2.0+b12"}, "b", []string{"1.1.0+b7"}},
+		{[]string{"1.2.0+b12", "1.0.0+b10", "1.1.0+b11"}, "b", []string{}},
+		{[]string{"1.0.0+20", "1.0.0+5", "1.0.1+15", "1.0.2"}, "", []string{"1.0.1+15"}},
+		{[]string{"2.0.0+ci.b3", "1.0.0-rc.1+b4", "1.0.0+b2"}, "b", []string{"1.0.0+b2", "2.0.0+ci.b3"}},
+	}
+
+	for _, test := range tests {
+		offenders, err := CheckBuildMonotonic(test.versions, test.prefix)
+		if err != nil {
+			t.Error(err)
+		}
+		if !reflect.DeepEqual(offenders, test.expected) {
+			t.Errorf("expected %v with prefix %q to flag %v but got %v", test.versions, test.prefix, test.expected, offenders)
+		}
+	}
+
+	if _, err := CheckBuildMonotonic([]string{"build"}, "b"); err == nil {
+		t.Error("expected an error for an invalid version")
+	}
+}
+
+func TestSetMeta(t *testing.T) {
+	tests := []struct {
+		meta     string
+		expected string
+		valid    bool
+	}{
+		{"build.42", "1.2.3-rc.1+build.42", true},
+		{"001", "1.2.3-rc.1+001", true},
+		{"sha-5114f85", "1.2.3-rc.1+sha-5114f85", true},
+		{"", "1.2.3-rc.1", true},
+		{"build..42", "", false},
+		{"build+42", "", false},
+		{"build 42", "", false},
+	}
+
+	ver := Semver{Major: 1, Minor: 2, Patch: 3, Prerelease: "rc.1", Meta: "old"}
+	for _, test := range tests {
+		result, err := ver.SetMeta(test.meta)
+		if (err == nil) != test.valid {
+			t.Errorf("expected SetMeta(%q) valid to be %v but got error %v", test.meta, test.valid, err)
+			continue
+		}
+		if test.valid && result.String() != test.expected {
+			t.Errorf("expected SetMeta(%q) to give %s but got %s", test.meta, test.expected, result)
+		}
+	}
+}
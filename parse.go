@@ -0,0 +1,299 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// DefaultMaxPrereleaseIdentifiers is the prerelease identifier limit ParseVersionWith
+// applies when ParseOptions leaves MaxPrereleaseIdentifiers unset.
+const DefaultMaxPrereleaseIdentifiers = 32
+
+// ErrTooManyIdentifiers is returned by ParseVersionWith when a prerelease tag has more
+// dot-separated identifiers than the configured limit.
+var ErrTooManyIdentifiers = errors.New("too many prerelease identifiers")
+
+// ErrLeadingZero is returned by ParseStrict, and by ParseVersionWith in strict mode,
+// when a major, minor, or patch component has a leading zero, as in "1.02.3". Use
+// ParseLoose to accept such cosmetically padded versions.
+var ErrLeadingZero = errors.New("leading zero in numeric component")
+
+// ErrEmptyPrerelease and ErrEmptyMeta are returned by ParseStrict, and by
+// ParseVersionWith in strict mode, when a "-" or "+" delimiter is not followed by
+// anything, as in "1.2.3-" or "1.2.3+". ParseVersion reads such input as if the delimiter
+// were absent.
+var (
+	ErrEmptyPrerelease = errors.New("empty prerelease tag")
+	ErrEmptyMeta       = errors.New("empty metadata")
+)
+
+// ErrInvalidMajor, ErrInvalidMinor, ErrInvalidPatch, ErrInvalidPrerelease, and
+// ErrInvalidMeta identify the segment of a version string that ParseStrict rejected. The
+// returned error wraps one of them along with the cause, such as ErrLeadingZero, when
+// there is one.
+var (
+	ErrInvalidMajor      = errors.New("invalid major version")
+	ErrInvalidMinor      = errors.New("invalid minor version")
+	ErrInvalidPatch      = errors.New("invalid patch version")
+	ErrInvalidPrerelease = errors.New("invalid prerelease tag")
+	ErrInvalidMeta       = errors.New("invalid metadata")
+)
+
+// ParseOptions adjusts how ParseVersionWith parses untrusted input.
+type ParseOptions struct {
+	// Strict parses with ParseStrict, rejecting input that ParseVersion tolerates but
+	// the specification forbids, such as leading zeros in the major, minor, and patch
+	// components or an empty prerelease tag or metadata after a trailing "-" or "+".
+	Strict bool
+
+	// MaxPrereleaseIdentifiers bounds the number of dot-separated identifiers in the
+	// prerelease tag, which bounds the work done when the version is compared. Zero uses
+	// DefaultMaxPrereleaseIdentifiers.
+	MaxPrereleaseIdentifiers int
+}
+
+// ParseVersionWith parses a version string like ParseVersion, then applies the limits in
+// opts. Use it for input from untrusted sources, such as a fuzzer or a public API, where
+// a prerelease tag with hundreds of identifiers would slow down every comparison.
+//
+// If the version cannot be parsed or exceeds a limit, the function returns an empty
+// Semver structure and the error. Limit errors wrap ErrTooManyIdentifiers.
+//
+// Example:
+//
+//	_, err := ParseVersionWith("1.0.0-a.b.c", ParseOptions{MaxPrereleaseIdentifiers: 2})
+//	fmt.Println(errors.Is(err, ErrTooManyIdentifiers)) // prints true
+func ParseVersionWith(v string, opts ParseOptions) (Semver, error) {
+	parseFn := ParseVersion
+	if opts.Strict {
+		parseFn = ParseStrict
+	}
+	ver, err := parseFn(v)
+	if err != nil {
+		return Semver{}, err
+	}
+
+	limit := opts.MaxPrereleaseIdentifiers
+	if limit == 0 {
+		limit = DefaultMaxPrereleaseIdentifiers
+	}
+	if ver.Prerelease != "" {
+		if n := strings.Count(ver.Prerelease, ".") + 1; n > limit {
+			return Semver{}, fmt.Errorf("%w in %q: %d exceeds the limit of %d", ErrTooManyIdentifiers, v, n, limit)
+		}
+	}
+	return ver, nil
+}
+
+// ParseStrict parses a version string exactly as the specification defines it, without
+// the normalization ParseVersion and the package-level functions apply. The whole string
+// must be a version: surrounding whitespace, a "v" prefix, or trailing text such as
+// "1.2.3really" are rejected, as are missing components, leading zeros in the major,
+// minor, and patch components or in numeric prerelease identifiers, empty identifiers,
+// and characters outside ASCII letters, digits, and hyphens in the prerelease tag and
+// metadata.
+//
+// If v is not a valid version, the function returns an empty Semver structure and an
+// error that wraps ErrInvalidMajor, ErrInvalidMinor, ErrInvalidPatch,
+// ErrInvalidPrerelease, or ErrInvalidMeta to identify the offending segment.
+//
+// Example:
+//
+//	_, err := ParseStrict("1.02.3")
+//	fmt.Println(errors.Is(err, ErrInvalidMinor)) // prints true
+//	fmt.Println(errors.Is(err, ErrLeadingZero))  // prints true
+func ParseStrict(v string) (Semver, error) {
+	rest, meta, hasMeta := strings.Cut(v, "+")
+	major, rest, _ := strings.Cut(rest, ".")
+	minor, rest, _ := strings.Cut(rest, ".")
+	patch, pre, hasPre := strings.Cut(rest, "-")
+
+	var ver Semver
+	var err error
+	if ver.Major, err = strictComponent(v, major, ErrInvalidMajor); err != nil {
+		return Semver{}, err
+	}
+	if ver.Minor, err = strictComponent(v, minor, ErrInvalidMinor); err != nil {
+		return Semver{}, err
+	}
+	if ver.Patch, err = strictComponent(v, patch, ErrInvalidPatch); err != nil {
+		return Semver{}, err
+	}
+
+	if hasPre && pre == "" {
+		return Semver{}, fmt.Errorf("%w in %q: %w", ErrInvalidPrerelease, v, ErrEmptyPrerelease)
+	}
+	if err := validIdentifiers(pre, true); err != nil {
+		return Semver{}, fmt.Errorf("%w %q in %q: %w", ErrInvalidPrerelease, pre, v, err)
+	}
+	if hasMeta && meta == "" {
+		return Semver{}, fmt.Errorf("%w in %q: %w", ErrInvalidMeta, v, ErrEmptyMeta)
+	}
+	if err := validIdentifiers(meta, false); err != nil {
+		return Semver{}, fmt.Errorf("%w %q in %q: %w", ErrInvalidMeta, meta, v, err)
+	}
+
+	ver.Prerelease, ver.Meta = pre, meta
+	return ver, nil
+}
+
+// strictComponent parses seg as a major, minor, or patch component of v, wrapping any
+// error in invalid.
+func strictComponent(v, seg string, invalid error) (int, error) {
+	var cause error
+	switch {
+	case seg == "":
+		cause = ErrEmptyComponent
+	case seg[0] == '-':
+		cause = ErrNegativeComponent
+	case !isNumeric(seg):
+		for _, r := range seg {
+			if r > unicode.MaxASCII && unicode.IsDigit(r) {
+				cause = ErrNonASCIIDigit
+				break
+			}
+		}
+		if cause == nil {
+			cause = errors.New("not a number")
+		}
+	case len(seg) > 1 && seg[0] == '0':
+		cause = ErrLeadingZero
+	}
+	if cause != nil {
+		return 0, fmt.Errorf("%w %q in %q: %w", invalid, seg, v, cause)
+	}
+
+	n, err := strconv.Atoi(seg)
+	if err != nil {
+		return 0, fmt.Errorf("%w %q in %q: %w", invalid, seg, v, err)
+	}
+	return n, nil
+}
+
+// MustParse is like ParseStrict but panics if v is not a valid version. It simplifies
+// the initialization of package-level variables and test fixtures.
+//
+// Example:
+//
+//	var minSupported = MustParse("1.4.0")
+func MustParse(v string) Semver {
+	ver, err := ParseStrict(v)
+	if err != nil {
+		panic(fmt.Sprintf("semver: MustParse(%q): %v", v, err))
+	}
+	return ver
+}
+
+// IsValid reports whether v is a valid version as ParseStrict defines it.
+//
+// Example:
+//
+//	fmt.Println(IsValid("1.2.3-rc.1"))     // prints true
+//	fmt.Println(IsValid("not1.2.3really")) // prints false
+func IsValid(v string) bool {
+	_, err := ParseStrict(v)
+	return err == nil
+}
+
+// ParseLoose parses a version string whose major, minor, and patch components may be
+// separated by a mix of "." and "-", such as "1-2-3" or "1.2-3".
+//
+// An optional "v" prefix is removed and build metadata after the first "+" is kept as
+// is. The leading numeric components are then read one at a time, accepting either "."
+// or "-" between them, until three have been recovered. Anything after the third
+// component must start with "-" and becomes the prerelease tag.
+//
+// When a "-" could either separate components or start a prerelease tag, the semver
+// interpretation wins: once three components have been read, the next "-" always starts
+// the prerelease tag. So "1.2-3" is 1.2.3, while "1.2.3-4" keeps "4" as its prerelease
+// tag and "1-2-3-4" is 1.2.3-4.
+//
+// Thousands separators left behind by spreadsheet exports are stripped from numeric
+// components, so "1.2,000.3" is 1.2000.3. A comma only counts as a separator when it
+// follows a digit and is followed by exactly three digits; any other comma ends the
+// component and makes the input invalid. Separators are recognized before components
+// are split, so a grouping comma never acts as a component delimiter.
+//
+// Zero-padded components are accepted and normalized, so "1.02.3" is 1.2.3 and compares
+// equal to it. Strict parsing with ParseVersionWith rejects the padded form.
+//
+// If three numeric components cannot be recovered, the function returns an empty Semver
+// structure and an error.
+//
+// Example:
+//
+//	ver, err := ParseLoose("1-2-3-rc.1")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(ver) // prints 1.2.3-rc.1
+func ParseLoose(v string) (Semver, error) {
+	rest := strings.TrimPrefix(strings.TrimSpace(v), "v")
+
+	var ver Semver
+	rest, ver.Meta, _ = strings.Cut(rest, "+")
+
+	parts := make([]int, 3)
+	for i := range parts {
+		if i > 0 {
+			if rest == "" || (rest[0] != '.' && rest[0] != '-') {
+				return Semver{}, fmt.Errorf("expected three numeric components in %q", v)
+			}
+			rest = rest[1:]
+		}
+
+		digits, end := scanDigits(rest)
+		if end == 0 {
+			return Semver{}, fmt.Errorf("%w in %q", ErrEmptyComponent, v)
+		}
+		n, err := strconv.Atoi(digits)
+		if err != nil {
+			return Semver{}, err
+		}
+		parts[i] = n
+		rest = rest[end:]
+	}
+
+	if rest != "" {
+		if rest[0] != '-' || len(rest) == 1 {
+			return Semver{}, fmt.Errorf("unexpected %q after version in %q", rest, v)
+		}
+		ver.Prerelease = rest[1:]
+	}
+
+	ver.Major, ver.Minor, ver.Patch = parts[0], parts[1], parts[2]
+	return ver, nil
+}
+
+// scanDigits reads the leading numeric component of s, skipping thousands separators,
+// and returns its digits and the number of bytes consumed.
+func scanDigits(s string) (string, int) {
+	var b strings.Builder
+	end := 0
+	for end < len(s) {
+		switch {
+		case isDigit(s[end]):
+			b.WriteByte(s[end])
+			end++
+		case s[end] == ',' && end > 0 && isGroup(s[end+1:]):
+			end++
+		default:
+			return b.String(), end
+		}
+	}
+	return b.String(), end
+}
+
+// isGroup reports whether s starts with exactly three digits, as follows a thousands
+// separator.
+func isGroup(s string) bool {
+	return len(s) >= 3 && isDigit(s[0]) && isDigit(s[1]) && isDigit(s[2]) && (len(s) == 3 || !isDigit(s[3]))
+}
+
+// isDigit reports whether c is an ASCII digit.
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,208 @@
+package semver
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestParseLoose(t *testing.T) {
+	tests := []struct {
+		v        string
+		expected Semver
+	}{
+		{"1-2-3", Semver{Major: 1, Minor: 2, Patch: 3}},
+		{"1.2-3", Semver{Major: 1, Minor: 2, Patch: 3}},
+		{"1-2.3", Semver{Major: 1, Minor: 2, Patch: 3}},
+		{"1.2.3", Semver{Major: 1, Minor: 2, Patch: 3}},
+		{"1.2.3-4", Semver{Major: 1, Minor: 2, Patch: 3, Prerelease: "4"}},
+		{"1-2-3-4", Semver{Major: 1, Minor: 2, Patch: 3, Prerelease: "4"}},
+		{"v1-2-3-rc.1+build", Semver{Major: 1, Minor: 2, Patch: 3, Prerelease: "rc.1", Meta: "build"}},
+		{"1.2,000.3", Semver{Major: 1, Minor: 2000, Patch: 3}},
+		{"1.1,234,567-8", Semver{Major: 1, Minor: 1234567, Patch: 8}},
+		{"2,024.1.0", Semver{Major: 2024, Minor: 1}},
+	}
+
+	for _, test := range tests {
+		ver, err := ParseLoose(test.v)
+		if err != nil {
+			t.Error(err)
+		}
+		if ver != test.expected {
+			t.Errorf("expected %s to parse as %+v but got %+v", test.v, test.expected, ver)
+		}
+	}
+
+	for _, bad := range []string{"", "1-2", "1.2", "1.2.3rc", "1.2.3-", "a-b-c", "1,2,3", "1.2,00.3", "1.2,0000.3", "1.,000.3"} {
+		if _, err := ParseLoose(bad); err == nil {
+			t.Errorf("expected an error for %q", bad)
+		}
+	}
+
+	if _, err := ParseLoose("1--3"); !errors.Is(err, ErrEmptyComponent) {
+		t.Errorf("expected ErrEmptyComponent but got %v", err)
+	}
+}
+
+func TestParseVersionWith(t *testing.T) {
+	tests := []struct {
+		version  string
+		limit    int
+		expected error
+	}{
+		{"1.0.0-a.b", 2, nil},
+		{"1.0.0-a.b.c", 2, ErrTooManyIdentifiers},
+		{"1.0.0", 1, nil},
+		{"1.0.0-" + strings.Repeat("x.", 31) + "x", 0, nil},
+		{"1.0.0-" + strings.Repeat("x.", 32) + "x", 0, ErrTooManyIdentifiers},
+	}
+
+	for _, test := range tests {
+		_, err := ParseVersionWith(test.version, ParseOptions{MaxPrereleaseIdentifiers: test.limit})
+		if !errors.Is(err, test.expected) {
+			t.Errorf("expected %s with limit %d to return %v but got %v", test.version, test.limit, test.expected, err)
+		}
+	}
+
+	ver, err := ParseVersionWith("1.2.3-rc.1+build", ParseOptions{})
+	if err != nil {
+		t.Error(err)
+	}
+	if ver != (Semver{Major: 1, Minor: 2, Patch: 3, Prerelease: "rc.1", Meta: "build"}) {
+		t.Errorf("unexpected version %+v", ver)
+	}
+}
+
+func TestZeroPadding(t *testing.T) {
+	tests := []struct {
+		padded    string
+		canonical string
+	}{
+		{"1.02.3", "1.2.3"},
+		{"01.2.3", "1.2.3"},
+		{"1.2.003-rc.1", "1.2.3-rc.1"},
+		{"1.00.0", "1.0.0"},
+	}
+
+	for _, test := range tests {
+		ver, err := ParseLoose(test.padded)
+		if err != nil {
+			t.Error(err)
+		}
+		if ver.String() != test.canonical {
+			t.Errorf("expected %s to parse loosely as %s but got %s", test.padded, test.canonical, ver)
+		}
+
+		if _, err := ParseVersionWith(test.padded, ParseOptions{Strict: true}); !errors.Is(err, ErrLeadingZero) {
+			t.Errorf("expected strict parsing of %s to fail with %v but got %v", test.padded, ErrLeadingZero, err)
+		}
+	}
+
+	for _, valid := range []string{"0.0.0", "1.0.10", "10.20.30-rc.1"} {
+		if _, err := ParseVersionWith(valid, ParseOptions{Strict: true}); err != nil {
+			t.Errorf("expected strict parsing of %s to succeed but got %v", valid, err)
+		}
+	}
+}
+
+func TestStrictEmptySuffix(t *testing.T) {
+	tests := []struct {
+		version  string
+		expected error
+	}{
+		{"1.2.3-", ErrEmptyPrerelease},
+		{"1.2.3+", ErrEmptyMeta},
+		{"1.2.3-+build", ErrEmptyPrerelease},
+		{"1.2.3-rc.1+", ErrEmptyMeta},
+		{"1.2.3-rc.1+build", nil},
+	}
+
+	for _, test := range tests {
+		if _, err := ParseVersionWith(test.version, ParseOptions{Strict: true}); !errors.Is(err, test.expected) {
+			t.Errorf("expected strict parsing of %s to return %v but got %v", test.version, test.expected, err)
+		}
+	}
+
+	// without strict mode the empty delimiters are tolerated
+	for _, version := range []string{"1.2.3-", "1.2.3+"} {
+		if _, err := ParseVersionWith(version, ParseOptions{}); err != nil {
+			t.Errorf("expected %s to parse without strict mode but got %v", version, err)
+		}
+	}
+}
+
+func TestParseStrict(t *testing.T) {
+	tests := []struct {
+		version string
+		segment error
+		cause   error
+	}{
+		{"not1.2.3really", ErrInvalidMajor, nil},
+		{"1.2.3really", ErrInvalidPatch, nil},
+		{"v1.2.3", ErrInvalidMajor, nil},
+		{" 1.2.3", ErrInvalidMajor, nil},
+		{"1.2", ErrInvalidPatch, ErrEmptyComponent},
+		{"1..3", ErrInvalidMinor, ErrEmptyComponent},
+		{"1.2.3.4", ErrInvalidPatch, nil},
+		{"01.2.3", ErrInvalidMajor, ErrLeadingZero},
+		{"1.02.3", ErrInvalidMinor, ErrLeadingZero},
+		{"-1.0.0", ErrInvalidMajor, ErrNegativeComponent},
+		{"1.-1.0", ErrInvalidMinor, ErrNegativeComponent},
+		{"1.2.٣", ErrInvalidPatch, ErrNonASCIIDigit},
+		{"1.2.99999999999999999999", ErrInvalidPatch, strconv.ErrRange},
+		{"1.2.3-", ErrInvalidPrerelease, ErrEmptyPrerelease},
+		{"1.2.3-rc..1", ErrInvalidPrerelease, nil},
+		{"1.2.3-rc.01", ErrInvalidPrerelease, nil},
+		{"1.2.3-rc_1", ErrInvalidPrerelease, nil},
+		{"1.2.3+", ErrInvalidMeta, ErrEmptyMeta},
+		{"1.2.3+build..1", ErrInvalidMeta, nil},
+		{"1.2.3+build+1", ErrInvalidMeta, nil},
+	}
+
+	for _, test := range tests {
+		_, err := ParseStrict(test.version)
+		if !errors.Is(err, test.segment) {
+			t.Errorf("expected %q to fail with %v but got %v", test.version, test.segment, err)
+		}
+		if test.cause != nil && !errors.Is(err, test.cause) {
+			t.Errorf("expected %q to fail with %v but got %v", test.version, test.cause, err)
+		}
+		if IsValid(test.version) {
+			t.Errorf("expected %q to be invalid", test.version)
+		}
+	}
+
+	valid := map[string]Semver{
+		"0.0.0":                        {},
+		"1.2.3":                        {Major: 1, Minor: 2, Patch: 3},
+		"10.20.30-rc.1-x.0+build.001":  {Major: 10, Minor: 20, Patch: 30, Prerelease: "rc.1-x.0", Meta: "build.001"},
+		"1.0.0-alpha-beta+build-123":   {Major: 1, Prerelease: "alpha-beta", Meta: "build-123"},
+		"1.0.0+21AF26D3----117B344092": {Major: 1, Meta: "21AF26D3----117B344092"},
+	}
+	for v, expected := range valid {
+		ver, err := ParseStrict(v)
+		if err != nil {
+			t.Errorf("expected %q to parse strictly but got %v", v, err)
+		}
+		if ver != expected {
+			t.Errorf("expected %q to parse as %+v but got %+v", v, expected, ver)
+		}
+		if !IsValid(v) {
+			t.Errorf("expected %q to be valid", v)
+		}
+	}
+}
+
+func TestMustParse(t *testing.T) {
+	if ver := MustParse("1.2.3-rc.1"); ver != (Semver{Major: 1, Minor: 2, Patch: 3, Prerelease: "rc.1"}) {
+		t.Errorf("unexpected version %+v", ver)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected MustParse to panic on an invalid version")
+		}
+	}()
+	MustParse("1.2")
+}
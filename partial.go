@@ -0,0 +1,99 @@
+package semver
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PartialVersion is a version pattern whose trailing components may be left out or
+// written as wildcards, such as "1.2.x", "1.*", or "*".
+type PartialVersion struct {
+	Semver
+
+	// Parts is the number of leading components the pattern fixes: 0 for "*", 1 for
+	// "1.x", 2 for "1.2.x", and 3 for a full version such as "1.2.3".
+	Parts int
+}
+
+// ParsePartialVersion parses a version pattern such as "1.2.x", "1.2.*", "1.x", "1", or
+// "*". The wildcards "x", "X", and "*" may only replace trailing components, and an
+// omitted component counts as a wildcard, so "1.2" is the same pattern as "1.2.x". A full
+// version may carry a prerelease tag and metadata.
+//
+// If the pattern cannot be parsed, the function returns an empty PartialVersion and the
+// error.
+//
+// Example:
+//
+//	p, err := ParsePartialVersion("1.2.x")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(p.Parts) // prints 2
+func ParsePartialVersion(s string) (PartialVersion, error) {
+	ver, parts, err := parsePartial(strings.TrimSpace(s))
+	if err != nil {
+		return PartialVersion{}, fmt.Errorf("invalid version pattern %q: %w", s, err)
+	}
+	return PartialVersion{Semver: ver, Parts: parts}, nil
+}
+
+// Match reports whether v matches the pattern. A full version matches versions of equal
+// precedence. A pattern with wildcards matches every release whose leading components
+// equal the fixed ones; as in a constraint, prereleases only match a full version that
+// names them, so "1.2.x" does not match 1.2.9-rc.1.
+func (p PartialVersion) Match(v Semver) bool {
+	if p.Parts == 3 {
+		return compare(p.Semver, v) == 0
+	}
+	if v.Prerelease != "" {
+		return false
+	}
+
+	switch p.Parts {
+	case 2:
+		return v.Major == p.Major && v.Minor == p.Minor
+	case 1:
+		return v.Major == p.Major
+	}
+	return true
+}
+
+// String returns the pattern with its wildcard components written as "x", such as
+// "1.2.x", or "*" when every component is a wildcard.
+func (p PartialVersion) String() string {
+	switch p.Parts {
+	case 0:
+		return "*"
+	case 1:
+		return fmt.Sprintf("%d.x", p.Major)
+	case 2:
+		return fmt.Sprintf("%d.%d.x", p.Major, p.Minor)
+	}
+	return p.Semver.String()
+}
+
+// Match takes a version pattern such as "1.2.x", "1.x", or "*" and a version string, and
+// reports whether the version matches the pattern as PartialVersion.Match defines it.
+//
+// If the pattern or the version cannot be parsed, the function returns false and the
+// error.
+//
+// Example:
+//
+//	ok, err := Match("1.2.x", "1.2.9")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(ok) // prints true
+func Match(pattern, version string) (bool, error) {
+	p, err := ParsePartialVersion(pattern)
+	if err != nil {
+		return false, err
+	}
+	ver, err := parse(version)
+	if err != nil {
+		return false, err
+	}
+	return p.Match(ver), nil
+}
@@ -0,0 +1,76 @@
+package semver
+
+import "testing"
+
+func TestParsePartialVersion(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		parts    int
+		expected string
+	}{
+		{"*", 0, "*"},
+		{"x", 0, "*"},
+		{"1", 1, "1.x"},
+		{"1.x", 1, "1.x"},
+		{"1.*.*", 1, "1.x"},
+		{"1.2", 2, "1.2.x"},
+		{"v1.2.X", 2, "1.2.x"},
+		{"1.2.*", 2, "1.2.x"},
+		{"1.2.3-rc.1", 3, "1.2.3-rc.1"},
+	}
+
+	for _, test := range tests {
+		p, err := ParsePartialVersion(test.pattern)
+		if err != nil {
+			t.Error(err)
+		}
+		if p.Parts != test.parts {
+			t.Errorf("expected %q to fix %d components but got %d", test.pattern, test.parts, p.Parts)
+		}
+		if result := p.String(); result != test.expected {
+			t.Errorf("expected %q to print as %s but got %s", test.pattern, test.expected, result)
+		}
+	}
+
+	for _, pattern := range []string{"", "x.2", "1.x.3", "1.2.3.x", "1.y"} {
+		if _, err := ParsePartialVersion(pattern); err == nil {
+			t.Errorf("expected an error for %q", pattern)
+		}
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		v        string
+		expected bool
+	}{
+		{"1.2.x", "1.2.9", true},
+		{"1.2.x", "1.3.0", false},
+		{"1.2.*", "1.2.0", true},
+		{"1.x", "1.99.3", true},
+		{"1.x", "2.0.0", false},
+		{"*", "0.0.1", true},
+		{"1.2.x", "1.2.9-rc.1", false},
+		{"1.2.3", "1.2.3+build.5", true},
+		{"1.2.3-rc.1", "1.2.3-rc.1", true},
+		{"1.2.3", "1.2.4", false},
+	}
+
+	for _, test := range tests {
+		result, err := Match(test.pattern, test.v)
+		if err != nil {
+			t.Error(err)
+		}
+		if result != test.expected {
+			t.Errorf("expected %s to match %q=%v but got %v", test.v, test.pattern, test.expected, result)
+		}
+	}
+
+	if _, err := Match("1.x.3", "1.2.3"); err == nil {
+		t.Error("expected an error for an invalid pattern")
+	}
+	if _, err := Match("1.x", "latest"); err == nil {
+		t.Error("expected an error for an invalid version")
+	}
+}
@@ -0,0 +1,194 @@
+package semver
+
+// Weights used by RiskScore. A stable release scores RiskBase, and each instability
+// signal adds its weight on top.
+const (
+	RiskBase       = 10 // every version
+	RiskUnstable   = 40 // major version 0
+	RiskPrerelease = 30 // any prerelease tag
+)
+
+// RiskScore returns a heuristic risk value for depending on a version.
+//
+// Every version starts at RiskBase. A 0.x version, whose API may change at any time,
+// adds RiskUnstable, and a prerelease adds RiskPrerelease. Higher scores mean riskier
+// versions, so 0.1.0-alpha scores above 0.5.0, which scores above 2.0.0.
+//
+// If the version string cannot be parsed, the function returns 0 and the error.
+//
+// Example:
+//
+//	score, err := RiskScore("0.5.0")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(score) // prints 50
+func RiskScore(v string) (int, error) {
+	ver, err := parse(v)
+	if err != nil {
+		return 0, err
+	}
+
+	score := RiskBase
+	if ver.Major == 0 {
+		score += RiskUnstable
+	}
+	if ver.Prerelease != "" {
+		score += RiskPrerelease
+	}
+
+	return score, nil
+}
+
+// CompatPolicy describes the rules WireCompatible applies to a client and server version
+// pair. Every enabled rule must hold; the zero value accepts any pair.
+type CompatPolicy struct {
+	// SameMajor requires the client and server to share a major version.
+	SameMajor bool
+	// ServerMinorAtLeast requires the server's minor version to be at least the client's,
+	// so the server understands everything the client may send. It is only meaningful
+	// together with SameMajor.
+	ServerMinorAtLeast bool
+}
+
+// Built-in compatibility policies for WireCompatible.
+var (
+	// SameMajorPolicy accepts any client and server on the same major version.
+	SameMajorPolicy = CompatPolicy{SameMajor: true}
+	// ServerAtLeastPolicy accepts a client and server on the same major version when the
+	// server's minor version is at least the client's.
+	ServerAtLeastPolicy = CompatPolicy{SameMajor: true, ServerMinorAtLeast: true}
+)
+
+// WireCompatible reports whether a client and server version can talk to each other
+// under the given policy.
+//
+// If either version string cannot be parsed, the function returns false and the error.
+//
+// Example:
+//
+//	ok, err := WireCompatible("1.4.0", "1.2.0", ServerAtLeastPolicy)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(ok) // prints false
+func WireCompatible(client, server string, policy CompatPolicy) (bool, error) {
+	c, err := parse(client)
+	if err != nil {
+		return false, err
+	}
+	s, err := parse(server)
+	if err != nil {
+		return false, err
+	}
+
+	if policy.SameMajor && c.Major != s.Major {
+		return false, nil
+	}
+	if policy.ServerMinorAtLeast && s.Minor < c.Minor {
+		return false, nil
+	}
+	return true, nil
+}
+
+// IsLTS reports whether s is a long-term support release according to pred, such as
+// EvenMinorLTS. A nil predicate reports false.
+func (s Semver) IsLTS(pred func(Semver) bool) bool {
+	return pred != nil && pred(s)
+}
+
+// EvenMinorLTS is an IsLTS predicate for ecosystems where every even minor line is a
+// long-term support line, so 1.2.x is LTS and 1.3.x is not.
+func EvenMinorLTS(s Semver) bool {
+	return s.Minor%2 == 0
+}
+
+// IsDeprecated reports whether v is deprecated under an "everything up to cutoff is
+// deprecated" policy, that is, whether v has a precedence lower than or equal to cutoff.
+//
+// If there is an error parsing either version string, the function returns false and the
+// error.
+//
+// Example:
+//
+//	deprecated, err := IsDeprecated("1.4.0", "1.5.0")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(deprecated) // prints true
+func IsDeprecated(v, cutoff string) (bool, error) {
+	result, err := Compare(v, cutoff)
+	if err != nil {
+		return false, err
+	}
+	return result <= 0, nil
+}
+
+// FilterActive returns the versions that are not deprecated under cutoff, as decided by
+// IsDeprecated, in their original order.
+//
+// If the cutoff or any version cannot be parsed, the function returns nil and the error.
+//
+// Example:
+//
+//	active, err := FilterActive([]string{"1.4.0", "1.6.0", "1.5.0"}, "1.5.0")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(active) // prints [1.6.0]
+func FilterActive(versions []string, cutoff string) ([]string, error) {
+	limit, err := parse(cutoff)
+	if err != nil {
+		return nil, err
+	}
+	vers, err := parseAll(versions)
+	if err != nil {
+		return nil, err
+	}
+
+	active := []string{}
+	for i, ver := range vers {
+		if compare(ver, limit) > 0 {
+			active = append(active, versions[i])
+		}
+	}
+	return active, nil
+}
+
+// MajorAllowed reports whether the major version of s is in allowed, for "we only
+// support v1 and v2" style platform policies.
+func (s Semver) MajorAllowed(allowed []int) bool {
+	for _, major := range allowed {
+		if s.Major == major {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterByMajor returns the versions whose major version is in allowed, as decided by
+// MajorAllowed, in their original order.
+//
+// If any version cannot be parsed, the function returns nil and the error.
+//
+// Example:
+//
+//	supported, err := FilterByMajor([]string{"1.4.0", "3.0.0", "2.1.0"}, []int{1, 2})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(supported) // prints [1.4.0 2.1.0]
+func FilterByMajor(versions []string, allowed []int) ([]string, error) {
+	vers, err := parseAll(versions)
+	if err != nil {
+		return nil, err
+	}
+
+	supported := []string{}
+	for i, ver := range vers {
+		if ver.MajorAllowed(allowed) {
+			supported = append(supported, versions[i])
+		}
+	}
+	return supported, nil
+}
@@ -0,0 +1,149 @@
+package semver
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRiskScore(t *testing.T) {
+	ordered := []string{"0.1.0-alpha", "0.5.0", "2.0.0-rc.1", "2.0.0"}
+
+	scores := make([]int, len(ordered))
+	for i, v := range ordered {
+		score, err := RiskScore(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		scores[i] = score
+	}
+
+	for i := 1; i < len(scores); i++ {
+		if scores[i-1] <= scores[i] {
+			t.Errorf("expected %s (%d) to score above %s (%d)", ordered[i-1], scores[i-1], ordered[i], scores[i])
+		}
+	}
+
+	if scores[len(scores)-1] != RiskBase {
+		t.Errorf("expected a stable release to score %d but got %d", RiskBase, scores[len(scores)-1])
+	}
+}
+
+func TestWireCompatible(t *testing.T) {
+	tests := []struct {
+		client   string
+		server   string
+		policy   CompatPolicy
+		expected bool
+	}{
+		{"1.4.0", "1.2.0", SameMajorPolicy, true},
+		{"1.4.0", "2.0.0", SameMajorPolicy, false},
+		{"1.4.0", "1.2.0", ServerAtLeastPolicy, false},
+		{"1.2.0", "1.4.0", ServerAtLeastPolicy, true},
+		{"1.2.5", "1.2.0", ServerAtLeastPolicy, true},
+		{"1.2.0", "2.4.0", ServerAtLeastPolicy, false},
+		{"1.0.0", "3.0.0", CompatPolicy{}, true},
+	}
+
+	for _, test := range tests {
+		ok, err := WireCompatible(test.client, test.server, test.policy)
+		if err != nil {
+			t.Error(err)
+		}
+		if ok != test.expected {
+			t.Errorf("expected client %s and server %s under %+v to be compatible=%t but got %t", test.client, test.server, test.policy, test.expected, ok)
+		}
+	}
+}
+
+func TestIsLTS(t *testing.T) {
+	tests := []struct {
+		v        string
+		expected bool
+	}{
+		{"1.2.0", true},
+		{"1.2.7", true},
+		{"1.3.0", false},
+		{"2.0.0", true},
+	}
+
+	for _, test := range tests {
+		ver, err := ParseVersion(test.v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := ver.IsLTS(EvenMinorLTS); got != test.expected {
+			t.Errorf("expected %s to be LTS=%t but got %t", test.v, test.expected, got)
+		}
+	}
+
+	if (Semver{Major: 1, Minor: 2}).IsLTS(nil) {
+		t.Error("expected a nil predicate to report false")
+	}
+}
+
+func TestIsDeprecated(t *testing.T) {
+	tests := []struct {
+		v        string
+		cutoff   string
+		expected bool
+	}{
+		{"1.4.0", "1.5.0", true},
+		{"1.5.0", "1.5.0", true},
+		{"1.5.0+build", "1.5.0", true},
+		{"1.5.1", "1.5.0", false},
+		{"1.6.0-rc.1", "1.5.0", false},
+	}
+
+	for _, test := range tests {
+		deprecated, err := IsDeprecated(test.v, test.cutoff)
+		if err != nil {
+			t.Error(err)
+		}
+		if deprecated != test.expected {
+			t.Errorf("expected %s deprecated by %s to be %v but got %v", test.v, test.cutoff, test.expected, deprecated)
+		}
+	}
+
+	active, err := FilterActive([]string{"1.4.0", "1.6.0", "1.5.0", "v2.0.0"}, "1.5.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"1.6.0", "v2.0.0"}; !reflect.DeepEqual(active, expected) {
+		t.Errorf("expected active versions %v but got %v", expected, active)
+	}
+
+	if _, err := FilterActive([]string{"1.4.0"}, "latest"); err == nil {
+		t.Error("expected an error for an invalid cutoff")
+	}
+}
+
+func TestMajorAllowed(t *testing.T) {
+	tests := []struct {
+		v        Semver
+		allowed  []int
+		expected bool
+	}{
+		{Semver{Major: 1, Minor: 4}, []int{1, 2}, true},
+		{Semver{Major: 2, Prerelease: "rc.1"}, []int{1, 2}, true},
+		{Semver{Major: 3}, []int{1, 2}, false},
+		{Semver{Major: 1}, nil, false},
+	}
+
+	for _, test := range tests {
+		if ok := test.v.MajorAllowed(test.allowed); ok != test.expected {
+			t.Errorf("expected %s allowed by %v to be %v but got %v", test.v, test.allowed, test.expected, ok)
+		}
+	}
+
+	supported, err := FilterByMajor([]string{"1.4.0", "3.0.0", "0.9.0", "v2.1.0", "2.0.0-rc.1"}, []int{1, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"1.4.0", "v2.1.0", "2.0.0-rc.1"}; !reflect.DeepEqual(supported, expected) {
+		t.Errorf("expected supported versions %v but got %v", expected, supported)
+	}
+
+	if _, err := FilterByMajor([]string{"1.4.0", "nope"}, []int{1}); err == nil {
+		t.Error("expected an error for an invalid version")
+	}
+}
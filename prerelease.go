@@ -0,0 +1,220 @@
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// PrereleaseAllowed reports whether the prerelease tag of s is permitted by a deny pattern.
+//
+// The function returns false when the prerelease tag matches deny, which lets release
+// gates block internal tags such as "wip" or "debug" from being published. A version
+// without a prerelease tag, or a nil deny pattern, always passes.
+//
+// Example:
+//
+//	deny := regexp.MustCompile(`^(wip|debug)(\.|$)`)
+//	ver, _ := ParseVersion("1.0.0-wip.3")
+//	fmt.Println(ver.PrereleaseAllowed(deny)) // prints false
+func (s Semver) PrereleaseAllowed(deny *regexp.Regexp) bool {
+	if s.Prerelease == "" || deny == nil {
+		return true
+	}
+	return !deny.MatchString(s.Prerelease)
+}
+
+// PrereleaseIdentifiers returns the dot-separated identifiers of the prerelease tag of
+// s, which Compare orders one by one: numeric identifiers numerically, alphanumeric
+// identifiers lexically, numeric below alphanumeric, and a shorter list below a longer
+// one that it prefixes. A version without a prerelease tag returns nil.
+//
+// Example:
+//
+//	ver, _ := ParseVersion("1.0.0-alpha.beta.1")
+//	fmt.Println(ver.PrereleaseIdentifiers()) // prints [alpha beta 1]
+func (s Semver) PrereleaseIdentifiers() []string {
+	if s.Prerelease == "" {
+		return nil
+	}
+	return strings.Split(s.Prerelease, ".")
+}
+
+// SetPrerelease returns s with its prerelease tag replaced by pre. An empty pre removes
+// the prerelease tag.
+//
+// If pre holds an empty identifier, a character other than ASCII letters, digits, and
+// hyphens, or a numeric identifier with a leading zero, the function returns an empty
+// Semver structure and an error.
+//
+// Example:
+//
+//	ver, _ := ParseVersion("1.2.3")
+//	pre, err := ver.SetPrerelease("rc.1")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(pre) // prints 1.2.3-rc.1
+func (s Semver) SetPrerelease(pre string) (Semver, error) {
+	if err := validIdentifiers(pre, true); err != nil {
+		return Semver{}, fmt.Errorf("invalid prerelease tag %q: %w", pre, err)
+	}
+	s.Prerelease = pre
+	return s, nil
+}
+
+// validIdentifiers checks a dot-separated list of identifiers as used in prerelease tags
+// and metadata. An empty list is valid. When numeric is true, numeric identifiers must not
+// have leading zeros.
+func validIdentifiers(ids string, numeric bool) error {
+	if ids == "" {
+		return nil
+	}
+	for _, id := range strings.Split(ids, ".") {
+		if id == "" {
+			return fmt.Errorf("empty identifier")
+		}
+		for i := 0; i < len(id); i++ {
+			c := id[i]
+			if !isDigit(c) && c != '-' && (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') {
+				return fmt.Errorf("invalid character %q in identifier %q", c, id)
+			}
+		}
+		if numeric && len(id) > 1 && id[0] == '0' && isNumeric(id) {
+			return fmt.Errorf("leading zero in numeric identifier %q", id)
+		}
+	}
+	return nil
+}
+
+// PrereleaseBase returns the prerelease tag of s without its trailing numeric identifiers.
+//
+// For "1.0.0-rc.2" the base is "rc", and for "1.0.0-beta.2.1.3" it is "beta". A
+// version without a prerelease tag, or whose prerelease tag is entirely numeric,
+// returns an empty string.
+//
+// Example:
+//
+//	ver, _ := ParseVersion("1.0.0-alpha.3")
+//	fmt.Println(ver.PrereleaseBase()) // prints alpha
+func (s Semver) PrereleaseBase() string {
+	if s.Prerelease == "" {
+		return ""
+	}
+
+	idents := strings.Split(s.Prerelease, ".")
+	n := len(idents)
+	for n > 0 && isNumeric(idents[n-1]) {
+		n--
+	}
+
+	return strings.Join(idents[:n], ".")
+}
+
+// PrereleaseSeries returns n consecutive prerelease versions of the core of s, numbered
+// from 1 on the prerelease base of s. For 1.0.0-rc.1 and n of 3 it returns 1.0.0-rc.1,
+// 1.0.0-rc.2, and 1.0.0-rc.3. Metadata is not carried over.
+//
+// If s has no prerelease base to extend, or n is negative, the function returns nil and
+// an error.
+//
+// Example:
+//
+//	ver, _ := ParseVersion("1.0.0-rc.1")
+//	series, err := ver.PrereleaseSeries(3)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(series) // prints [1.0.0-rc.1 1.0.0-rc.2 1.0.0-rc.3]
+func (s Semver) PrereleaseSeries(n int) ([]Semver, error) {
+	base := s.PrereleaseBase()
+	if base == "" {
+		return nil, fmt.Errorf("%s has no prerelease base to extend", s)
+	}
+	if n < 0 {
+		return nil, fmt.Errorf("invalid series length %d", n)
+	}
+
+	series := make([]Semver, n)
+	for i := range series {
+		series[i] = s.core()
+		series[i].Prerelease = base + "." + strconv.Itoa(i+1)
+	}
+	return series, nil
+}
+
+// isNumeric reports whether s is a non-empty string of ASCII digits.
+func isNumeric(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// stages lists the well-known prerelease stages in ascending order.
+var stages = []string{"alpha", "beta", "rc"}
+
+// stageRank returns the position of stage in stages, or -1 if it is not a known stage.
+func stageRank(stage string) int {
+	for i, s := range stages {
+		if s == stage {
+			return i
+		}
+	}
+	return -1
+}
+
+// comparePrerelease compares two prerelease tags identifier by identifier using cmp.
+// When every identifier of the shorter tag equals the corresponding identifier of the
+// longer one, the longer tag ranks higher. Tags of any depth are supported.
+func comparePrerelease(a, b string, cmp func(a, b string) int) int {
+	for {
+		id1, rest1, more1 := strings.Cut(a, ".")
+		id2, rest2, more2 := strings.Cut(b, ".")
+		if result := cmp(id1, id2); result != 0 {
+			return result
+		}
+		switch {
+		case more1 && more2:
+			a, b = rest1, rest2
+		case more1:
+			return 1
+		case more2:
+			return -1
+		default:
+			return 0
+		}
+	}
+}
+
+// compareIdentifier compares two prerelease identifiers. Numeric identifiers are
+// compared numerically and alphanumeric identifiers lexically, with numeric identifiers
+// ranking below alphanumeric ones.
+func compareIdentifier(a, b string) int {
+	num1, num2 := isNumeric(a), isNumeric(b)
+	switch {
+	case num1 && num2:
+		return compareNumeric(a, b)
+	case num1:
+		return -1
+	case num2:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// compareNumeric compares two strings of ASCII digits by numeric value without
+// converting them, so identifiers of any length can be compared.
+func compareNumeric(a, b string) int {
+	a, b = strings.TrimLeft(a, "0"), strings.TrimLeft(b, "0")
+	if result := compareInts(len(a), len(b)); result != 0 {
+		return result
+	}
+	return strings.Compare(a, b)
+}
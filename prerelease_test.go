@@ -0,0 +1,193 @@
+package semver
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestPrereleaseAllowed(t *testing.T) {
+	deny := regexp.MustCompile(`^wip(\.|$)`)
+
+	tests := []struct {
+		v        string
+		expected bool
+	}{
+		{"1.0.0-wip", false},
+		{"1.0.0-wip.2", false},
+		{"1.0.0-rc.1", true},
+		{"1.0.0", true},
+		{"1.0.0+wip", true},
+	}
+
+	for _, test := range tests {
+		ver, err := ParseVersion(test.v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := ver.PrereleaseAllowed(deny); got != test.expected {
+			t.Errorf("expected %s to be allowed=%t but got %t", test.v, test.expected, got)
+		}
+	}
+
+	if ver := (Semver{Major: 1, Prerelease: "wip"}); !ver.PrereleaseAllowed(nil) {
+		t.Error("expected a nil deny pattern to allow every prerelease")
+	}
+}
+
+func TestPrereleaseIdentifiers(t *testing.T) {
+	tests := []struct {
+		v        string
+		expected []string
+	}{
+		{"1.0.0-alpha.beta.1", []string{"alpha", "beta", "1"}},
+		{"1.0.0-rc", []string{"rc"}},
+		{"1.0.0-x-y.7+build.1", []string{"x-y", "7"}},
+		{"1.0.0", nil},
+	}
+
+	for _, test := range tests {
+		ver, err := ParseVersion(test.v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ids := ver.PrereleaseIdentifiers(); !reflect.DeepEqual(ids, test.expected) {
+			t.Errorf("expected %s to have prerelease identifiers %q but got %q", test.v, test.expected, ids)
+		}
+	}
+}
+
+func TestPrereleaseIdentifierPrecedence(t *testing.T) {
+	tests := []struct {
+		v1       string
+		v2       string
+		expected int
+	}{
+		{"1.0.0-alpha.2", "1.0.0-alpha.10", -1},
+		{"1.0.0-rc.1", "1.0.0-beta.11", 1},
+		{"1.0.0-beta.11", "1.0.0-beta.2", 1},
+		{"1.0.0-alpha.1", "1.0.0-alpha.beta", -1},
+		{"1.0.0-alpha", "1.0.0-alpha.1", -1},
+		{"1.0.0-1.2.3", "1.0.0-1.2.3.0", -1},
+		{"1.0.0-99999999999999999999", "1.0.0-100000000000000000000", -1},
+	}
+
+	for _, test := range tests {
+		c, err := Compare(test.v1, test.v2)
+		if err != nil {
+			t.Error(err)
+		}
+		if c != test.expected {
+			t.Errorf("expected %s and %s to be %d but got %d", test.v1, test.v2, test.expected, c)
+		}
+	}
+}
+
+func TestPrereleaseBase(t *testing.T) {
+	tests := []struct {
+		v        string
+		expected string
+	}{
+		{"1.0.0-rc.2", "rc"},
+		{"1.0.0-beta.2.1.3", "beta"},
+		{"1.0.0-alpha.beta.1", "alpha.beta"},
+		{"1.0.0-alpha", "alpha"},
+		{"1.0.0-1", ""},
+		{"1.0.0", ""},
+	}
+
+	for _, test := range tests {
+		ver, err := ParseVersion(test.v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if base := ver.PrereleaseBase(); base != test.expected {
+			t.Errorf("expected %s to have prerelease base %q but got %q", test.v, test.expected, base)
+		}
+	}
+}
+
+func TestPrereleaseSeries(t *testing.T) {
+	tests := []struct {
+		v        string
+		n        int
+		expected string
+	}{
+		{"1.0.0-rc.1", 3, "[1.0.0-rc.1 1.0.0-rc.2 1.0.0-rc.3]"},
+		{"1.0.0-beta.7+build", 2, "[1.0.0-beta.1 1.0.0-beta.2]"},
+		{"2.1.0-alpha", 1, "[2.1.0-alpha.1]"},
+		{"1.0.0-rc.1", 0, "[]"},
+	}
+
+	for _, test := range tests {
+		ver, err := ParseVersion(test.v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		series, err := ver.PrereleaseSeries(test.n)
+		if err != nil {
+			t.Error(err)
+		}
+		if result := fmt.Sprint(series); result != test.expected {
+			t.Errorf("expected %d increments of %s to be %s but got %s", test.n, test.v, test.expected, result)
+		}
+	}
+
+	if _, err := (Semver{Major: 1}).PrereleaseSeries(3); err == nil {
+		t.Error("expected an error without a prerelease base")
+	}
+	if _, err := (Semver{Major: 1, Prerelease: "7"}).PrereleaseSeries(3); err == nil {
+		t.Error("expected an error for a numeric prerelease")
+	}
+}
+
+func TestComparePrerelease(t *testing.T) {
+	tests := []testCase{
+		{"1.0.0-beta.2.1.3", "1.0.0-beta.2.1.10", -1},
+		{"1.0.0-beta.2.1.10", "1.0.0-beta.2.1.3", 1},
+		{"1.0.0-beta.2.1.3", "1.0.0-beta.2.1.3", 0},
+		{"1.0.0-beta.2.1", "1.0.0-beta.2.1.3", -1},
+		{"1.0.0-beta.2.1.3.4.5.6.7", "1.0.0-beta.2.1.3.4.5.6.8", -1},
+		{"1.0.0-beta.2.1.3.x", "1.0.0-beta.2.1.3.999", 1},
+		{"1.0.0-beta.99999999999999999999", "1.0.0-beta.100000000000000000000", -1},
+	}
+
+	for _, test := range tests {
+		c, err := Compare(test.v1, test.v2)
+		if err != nil {
+			t.Error(err)
+		}
+		if c != test.expected {
+			t.Errorf("expected %s and %s to be %d but got %d", test.v1, test.v2, test.expected, c)
+		}
+	}
+}
+
+func TestSetPrerelease(t *testing.T) {
+	tests := []struct {
+		pre      string
+		expected string
+		valid    bool
+	}{
+		{"rc.1", "1.2.3-rc.1+build", true},
+		{"alpha-beta.0", "1.2.3-alpha-beta.0+build", true},
+		{"", "1.2.3+build", true},
+		{"rc..1", "", false},
+		{"rc_1", "", false},
+		{"rc.01", "", false},
+		{"rc.", "", false},
+	}
+
+	ver := Semver{Major: 1, Minor: 2, Patch: 3, Prerelease: "beta", Meta: "build"}
+	for _, test := range tests {
+		result, err := ver.SetPrerelease(test.pre)
+		if (err == nil) != test.valid {
+			t.Errorf("expected SetPrerelease(%q) valid to be %v but got error %v", test.pre, test.valid, err)
+			continue
+		}
+		if test.valid && result.String() != test.expected {
+			t.Errorf("expected SetPrerelease(%q) to give %s but got %s", test.pre, test.expected, result)
+		}
+	}
+}
@@ -0,0 +1,458 @@
+package semver
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// VersionTime pairs a version string with the time it was released.
+type VersionTime struct {
+	Version string
+	Time    time.Time
+}
+
+// Cadence computes the average number of days between consecutive releases.
+//
+// The entries are ordered by semantic version precedence, not by timestamp, and the
+// interval between each adjacent pair is averaged. An entry released earlier than the
+// version before it contributes a negative interval.
+//
+// If fewer than two entries are given, or any version cannot be parsed, the function
+// returns 0 and an error.
+//
+// Example:
+//
+//	avg, err := Cadence([]VersionTime{
+//	    {"1.0.0", time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)},
+//	    {"1.1.0", time.Date(2023, 1, 11, 0, 0, 0, 0, time.UTC)},
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(avg) // prints 10
+func Cadence(entries []VersionTime) (avgDays float64, err error) {
+	if len(entries) < 2 {
+		return 0, fmt.Errorf("at least two releases are required")
+	}
+
+	type entry struct {
+		ver  Semver
+		time time.Time
+	}
+	sorted := make([]entry, 0, len(entries))
+	for _, e := range entries {
+		ver, err := parse(e.Version)
+		if err != nil {
+			return 0, fmt.Errorf("invalid version %q: %w", e.Version, err)
+		}
+		sorted = append(sorted, entry{ver, e.Time})
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return compare(sorted[i].ver, sorted[j].ver) < 0
+	})
+
+	var total time.Duration
+	for i := 1; i < len(sorted); i++ {
+		total += sorted[i].time.Sub(sorted[i-1].time)
+	}
+
+	return total.Hours() / 24 / float64(len(sorted)-1), nil
+}
+
+// Promote moves s to the next stage of the prerelease ladder: alpha, beta, rc, and
+// finally the release.
+//
+// The new stage starts at ".1" and metadata is cleared, so 1.0.0-alpha.3 becomes
+// 1.0.0-beta.1 and 1.0.0-rc.5 becomes the release 1.0.0.
+//
+// If s is already a release, or its PrereleaseBase is not a known stage, the function
+// returns an empty Semver structure and an error.
+//
+// Example:
+//
+//	ver, _ := ParseVersion("1.0.0-alpha.3+build.7")
+//	next, err := ver.Promote()
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(next) // prints 1.0.0-beta.1
+func (s Semver) Promote() (Semver, error) {
+	if s.Prerelease == "" {
+		return Semver{}, fmt.Errorf("%s is already a release", s)
+	}
+
+	rank := stageRank(s.PrereleaseBase())
+	if rank < 0 {
+		return Semver{}, fmt.Errorf("unknown prerelease stage %q", s.PrereleaseBase())
+	}
+
+	next := s.core()
+	if rank+1 < len(stages) {
+		next.Prerelease = stages[rank+1] + ".1"
+	}
+	return next, nil
+}
+
+// ProductionNext returns the next version allowed in production, where versions are
+// never prereleases. A prerelease is finalized to its release by clearing the prerelease
+// tag and metadata, so 1.2.0-rc.3 becomes 1.2.0, and a release is bumped by one patch, so
+// 1.2.0 becomes 1.2.1.
+//
+// If the patch component would overflow, the function returns an empty Semver structure
+// and an error.
+//
+// Example:
+//
+//	ver, _ := ParseVersion("1.2.0-rc.3")
+//	next, err := ver.ProductionNext()
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(next) // prints 1.2.0
+func (s Semver) ProductionNext() (Semver, error) {
+	if s.Prerelease != "" {
+		return s.core(), nil
+	}
+	return s.Next("patch")
+}
+
+// Next returns the version that follows s when bumping the given level, which is one of
+// "major", "minor", or "patch".
+//
+// The bumped component is incremented, every lower component is reset to zero, and the
+// prerelease tag and metadata are cleared, so bumping 1.2.3-rc.1 by "minor" yields 1.3.0.
+//
+// If level is not recognized, or the bumped component is already math.MaxInt, the
+// function returns an empty Semver structure and an error.
+//
+// Example:
+//
+//	ver, _ := ParseVersion("1.2.3")
+//	next, err := ver.Next("minor")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(next) // prints 1.3.0
+func (s Semver) Next(level string) (Semver, error) {
+	lvl, err := parseLevel(level)
+	if err != nil {
+		return Semver{}, err
+	}
+
+	switch lvl {
+	case MajorChange:
+		if !s.CanIncMajor() {
+			return Semver{}, fmt.Errorf("major component of %s would overflow", s)
+		}
+		return Semver{Major: s.Major + 1}, nil
+	case MinorChange:
+		if !s.CanIncMinor() {
+			return Semver{}, fmt.Errorf("minor component of %s would overflow", s)
+		}
+		return Semver{Major: s.Major, Minor: s.Minor + 1}, nil
+	}
+	if !s.CanIncPatch() {
+		return Semver{}, fmt.Errorf("patch component of %s would overflow", s)
+	}
+	return Semver{Major: s.Major, Minor: s.Minor, Patch: s.Patch + 1}, nil
+}
+
+// IncMajor returns the next major version after s, as Next("major") does: 1.2.3-rc.1
+// becomes 2.0.0.
+func (s Semver) IncMajor() (Semver, error) {
+	return s.Next("major")
+}
+
+// IncMinor returns the next minor version after s, as Next("minor") does: 1.2.3-rc.1
+// becomes 1.3.0.
+func (s Semver) IncMinor() (Semver, error) {
+	return s.Next("minor")
+}
+
+// IncPatch returns the next patch version after s, as Next("patch") does: 1.2.3 becomes
+// 1.2.4.
+func (s Semver) IncPatch() (Semver, error) {
+	return s.Next("patch")
+}
+
+// BumpPrerelease returns s with the trailing numeric identifier of its prerelease tag
+// incremented, so 1.0.0-rc.1 becomes 1.0.0-rc.2 and 1.0.0-beta.2.9 becomes
+// 1.0.0-beta.2.10. A prerelease tag that does not end in a numeric identifier gets ".1"
+// appended, so 1.0.0-alpha becomes 1.0.0-alpha.1. Metadata is cleared.
+//
+// If s has no prerelease tag, or the identifier would overflow, the function returns an
+// empty Semver structure and an error.
+//
+// Example:
+//
+//	ver, _ := ParseVersion("1.0.0-rc.1")
+//	next, err := ver.BumpPrerelease()
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(next) // prints 1.0.0-rc.2
+func (s Semver) BumpPrerelease() (Semver, error) {
+	if s.Prerelease == "" {
+		return Semver{}, fmt.Errorf("%s has no prerelease tag to bump", s)
+	}
+
+	ids := strings.Split(s.Prerelease, ".")
+	last := ids[len(ids)-1]
+	if isNumeric(last) {
+		n, err := strconv.Atoi(last)
+		if err != nil || n == math.MaxInt {
+			return Semver{}, fmt.Errorf("prerelease identifier %q of %s would overflow", last, s)
+		}
+		ids[len(ids)-1] = strconv.Itoa(n + 1)
+	} else {
+		ids = append(ids, "1")
+	}
+
+	next := s.core()
+	next.Prerelease = strings.Join(ids, ".")
+	return next, nil
+}
+
+// CanIncMajor reports whether the major component can be incremented without
+// overflowing an int.
+func (s Semver) CanIncMajor() bool {
+	return s.Major < math.MaxInt
+}
+
+// CanIncMinor reports whether the minor component can be incremented without
+// overflowing an int.
+func (s Semver) CanIncMinor() bool {
+	return s.Minor < math.MaxInt
+}
+
+// CanIncPatch reports whether the patch component can be incremented without
+// overflowing an int.
+//
+// Example:
+//
+//	ver := Semver{Major: 2023, Minor: 5, Patch: math.MaxInt}
+//	fmt.Println(ver.CanIncPatch()) // prints false
+func (s Semver) CanIncPatch() bool {
+	return s.Patch < math.MaxInt
+}
+
+// BumpReason returns a changelog section header describing the release from from to to,
+// based on the most significant component that changed:
+//
+//   - "Major release: breaking changes"
+//   - "Minor release: new features"
+//   - "Patch release: bug fixes"
+//
+// A target with a prerelease tag gets "Prerelease: upcoming changes for testing", and
+// promoting a prerelease to the release of the same version gets "Stable release:
+// prerelease promoted".
+//
+// If either version string cannot be parsed, or to is not newer than from, the function
+// returns an empty string and an error.
+//
+// Example:
+//
+//	reason, err := BumpReason("1.2.3", "1.3.0")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(reason) // prints Minor release: new features
+func BumpReason(from, to string) (string, error) {
+	start, err := parse(from)
+	if err != nil {
+		return "", err
+	}
+	target, err := parse(to)
+	if err != nil {
+		return "", err
+	}
+	if compare(target, start) <= 0 {
+		return "", fmt.Errorf("%s is not newer than %s", to, from)
+	}
+
+	if target.Prerelease != "" {
+		return "Prerelease: upcoming changes for testing", nil
+	}
+	switch diff(start.core(), target.core()) {
+	case MajorChange:
+		return "Major release: breaking changes", nil
+	case MinorChange:
+		return "Minor release: new features", nil
+	case PatchChange:
+		return "Patch release: bug fixes", nil
+	}
+	return "Stable release: prerelease promoted", nil
+}
+
+// CalVerLayout selects how CalVerFromTimeLayout maps a date onto the major and minor
+// components.
+type CalVerLayout int
+
+const (
+	// CalVerYearMonth produces YYYY.M.PATCH, such as 2023.5.0.
+	CalVerYearMonth CalVerLayout = iota
+	// CalVerYearWeek produces YYYY.W.PATCH using the ISO 8601 week, such as 2023.18.0.
+	CalVerYearWeek
+)
+
+// CalVerFromTime returns a calendar version of the form YYYY.M.PATCH for t.
+//
+// Example:
+//
+//	ver := CalVerFromTime(time.Date(2023, time.May, 1, 0, 0, 0, 0, time.UTC), 2)
+//	fmt.Println(ver) // prints 2023.5.2
+func CalVerFromTime(t time.Time, patch int) Semver {
+	return CalVerFromTimeLayout(t, CalVerYearMonth, patch)
+}
+
+// CalVerFromTimeLayout returns a calendar version for t using the given layout. With
+// CalVerYearWeek the year is the ISO 8601 year that owns the week, which can differ from
+// the calendar year in the first and last days of a year.
+//
+// Example:
+//
+//	ver := CalVerFromTimeLayout(time.Date(2023, time.May, 1, 0, 0, 0, 0, time.UTC), CalVerYearWeek, 0)
+//	fmt.Println(ver) // prints 2023.18.0
+func CalVerFromTimeLayout(t time.Time, layout CalVerLayout, patch int) Semver {
+	if layout == CalVerYearWeek {
+		year, week := t.ISOWeek()
+		return Semver{Major: year, Minor: week, Patch: patch}
+	}
+	return Semver{Major: t.Year(), Minor: int(t.Month()), Patch: patch}
+}
+
+// NextAvailable bumps base by the given level, which is one of "major", "minor", or
+// "patch", and keeps bumping until it reaches a version not already in existing.
+//
+// Existing versions are matched by precedence, so metadata is ignored and a prerelease
+// such as 1.3.0-rc.1 does not block 1.3.0.
+//
+// If any version string cannot be parsed, or bump is not recognized, the function returns
+// an empty Semver structure and the error.
+//
+// Example:
+//
+//	next, err := NextAvailable("1.2.0", "minor", []string{"1.3.0"})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(next) // prints 1.4.0
+func NextAvailable(base string, bump string, existing []string) (Semver, error) {
+	ver, err := parse(base)
+	if err != nil {
+		return Semver{}, err
+	}
+	vers, err := parseAll(existing)
+	if err != nil {
+		return Semver{}, err
+	}
+
+	taken := make(map[Semver]bool, len(vers))
+	for _, v := range vers {
+		taken[v.key()] = true
+	}
+
+	for {
+		ver, err = ver.Next(bump)
+		if err != nil {
+			return Semver{}, err
+		}
+		if !taken[ver] {
+			return ver, nil
+		}
+	}
+}
+
+// IsReleased reports whether s is a final release, that is, it has no prerelease tag.
+// Metadata does not matter, so 1.2.3+build is released while 1.2.3-rc.1 is not.
+func (s Semver) IsReleased() bool {
+	return s.Prerelease == ""
+}
+
+// IsMinorInitial reports whether s is the first release of its minor line, such as
+// 1.3.0. Prereleases are not releases, so 1.3.0-rc.1 returns false.
+func (s Semver) IsMinorInitial() bool {
+	return s.Patch == 0 && s.IsReleased()
+}
+
+// IsMajorInitial reports whether s is the first release of its major line, such as
+// 2.0.0. Every major initial release is also a minor initial release.
+func (s Semver) IsMajorInitial() bool {
+	return s.Minor == 0 && s.IsMinorInitial()
+}
+
+// ApplyBumps applies a sequence of bumps to base in order and returns the result. Each
+// bump is one of "major", "minor", or "patch", as accepted by Next.
+//
+// If base cannot be parsed or a bump is not recognized, the function returns an empty
+// Semver structure and an error.
+//
+// Example:
+//
+//	ver, err := ApplyBumps("1.0.0", []string{"patch", "minor", "major"})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(ver) // prints 2.0.0
+func ApplyBumps(base string, bumps []string) (Semver, error) {
+	ver, err := parse(base)
+	if err != nil {
+		return Semver{}, err
+	}
+
+	for i, bump := range bumps {
+		ver, err = ver.Next(bump)
+		if err != nil {
+			return Semver{}, fmt.Errorf("bump %d: %w", i, err)
+		}
+	}
+
+	return ver, nil
+}
+
+// PlanReleases returns the next count versions after start, following a cadence of bumps
+// that repeats as needed. The cadence is a comma-separated list of levels accepted by
+// Next, such as "patch" or "minor,patch,patch".
+//
+// If start cannot be parsed, count is negative, or the cadence holds an unknown level,
+// the function returns nil and an error.
+//
+// Example:
+//
+//	plan, err := PlanReleases("1.0.0", 4, "minor,patch")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(plan) // prints [1.1.0 1.1.1 1.2.0 1.2.1]
+func PlanReleases(start string, count int, cadence string) ([]Semver, error) {
+	ver, err := parse(start)
+	if err != nil {
+		return nil, err
+	}
+	if count < 0 {
+		return nil, fmt.Errorf("invalid release count %d", count)
+	}
+
+	pattern := strings.Split(cadence, ",")
+	for i, level := range pattern {
+		pattern[i] = strings.TrimSpace(level)
+		if _, err := parseLevel(pattern[i]); err != nil {
+			return nil, fmt.Errorf("invalid cadence %q: %w", cadence, err)
+		}
+	}
+
+	plan := make([]Semver, count)
+	for i := range plan {
+		ver, err = ver.Next(pattern[i%len(pattern)])
+		if err != nil {
+			return nil, err
+		}
+		plan[i] = ver
+	}
+	return plan, nil
+}
@@ -0,0 +1,418 @@
+package semver
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestCadence(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2023, time.January, d, 0, 0, 0, 0, time.UTC) }
+
+	avg, err := Cadence([]VersionTime{
+		{"1.1.0", day(11)},
+		{"1.0.0", day(1)},
+		{"1.2.0", day(31)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if avg != 15 {
+		t.Errorf("expected an average of 15 days but got %v", avg)
+	}
+
+	if _, err := Cadence([]VersionTime{{"1.0.0", day(1)}}); err == nil {
+		t.Error("expected an error for a single release")
+	}
+	if _, err := Cadence([]VersionTime{{"1.0.0", day(1)}, {"bad", day(2)}}); err == nil {
+		t.Error("expected an error for an invalid version")
+	}
+}
+
+func TestPromote(t *testing.T) {
+	ver, err := ParseVersion("1.0.0-alpha.3+build.7")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []string{"1.0.0-beta.1", "1.0.0-rc.1", "1.0.0"} {
+		ver, err = ver.Promote()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ver.String() != expected {
+			t.Errorf("expected promotion to %s but got %s", expected, ver)
+		}
+	}
+
+	if _, err := ver.Promote(); err == nil {
+		t.Error("expected an error when promoting a release")
+	}
+
+	rc := Semver{Major: 2, Minor: 1, Prerelease: "rc.5"}
+	if next, err := rc.Promote(); err != nil || next.String() != "2.1.0" {
+		t.Errorf("expected 2.1.0-rc.5 to promote to 2.1.0 but got %s (%v)", next, err)
+	}
+
+	nightly := Semver{Major: 1, Prerelease: "nightly.4"}
+	if _, err := nightly.Promote(); err == nil {
+		t.Error("expected an error for an unknown stage")
+	}
+}
+
+func TestNext(t *testing.T) {
+	tests := []struct {
+		v        string
+		level    string
+		expected string
+	}{
+		{"1.2.3", "major", "2.0.0"},
+		{"1.2.3", "minor", "1.3.0"},
+		{"1.2.3", "patch", "1.2.4"},
+		{"1.2.3-rc.1+build", "minor", "1.3.0"},
+	}
+
+	for _, test := range tests {
+		ver, err := ParseVersion(test.v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		next, err := ver.Next(test.level)
+		if err != nil {
+			t.Error(err)
+		}
+		if next.String() != test.expected {
+			t.Errorf("expected %s bumped by %s to be %s but got %s", test.v, test.level, test.expected, next)
+		}
+	}
+
+	if _, err := (Semver{Major: 1}).Next("build"); err == nil {
+		t.Error("expected an error for an unknown level")
+	}
+	if _, err := (Semver{Major: 1, Patch: math.MaxInt}).Next("patch"); err == nil {
+		t.Error("expected an error for an overflowing patch")
+	}
+	if _, err := (Semver{Major: 1, Patch: math.MaxInt}).Next("minor"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCanInc(t *testing.T) {
+	tests := []struct {
+		ver   Semver
+		major bool
+		minor bool
+		patch bool
+	}{
+		{Semver{Major: 1, Minor: 2, Patch: 3}, true, true, true},
+		{Semver{Major: math.MaxInt}, false, true, true},
+		{Semver{Minor: math.MaxInt}, true, false, true},
+		{Semver{Patch: math.MaxInt}, true, true, false},
+		{Semver{Major: math.MaxInt - 1, Minor: math.MaxInt - 1, Patch: math.MaxInt - 1}, true, true, true},
+	}
+
+	for _, test := range tests {
+		if result := test.ver.CanIncMajor(); result != test.major {
+			t.Errorf("expected CanIncMajor of %+v to be %v but got %v", test.ver, test.major, result)
+		}
+		if result := test.ver.CanIncMinor(); result != test.minor {
+			t.Errorf("expected CanIncMinor of %+v to be %v but got %v", test.ver, test.minor, result)
+		}
+		if result := test.ver.CanIncPatch(); result != test.patch {
+			t.Errorf("expected CanIncPatch of %+v to be %v but got %v", test.ver, test.patch, result)
+		}
+	}
+}
+
+func TestCalVerFromTime(t *testing.T) {
+	date := time.Date(2023, time.May, 1, 12, 0, 0, 0, time.UTC)
+
+	if ver := CalVerFromTime(date, 2); ver.String() != "2023.5.2" {
+		t.Errorf("expected 2023.5.2 but got %s", ver)
+	}
+
+	tests := []struct {
+		t        time.Time
+		layout   CalVerLayout
+		expected string
+	}{
+		{date, CalVerYearMonth, "2023.5.0"},
+		{date, CalVerYearWeek, "2023.18.0"},
+		{time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC), CalVerYearWeek, "2020.53.0"},
+	}
+
+	for _, test := range tests {
+		if ver := CalVerFromTimeLayout(test.t, test.layout, 0); ver.String() != test.expected {
+			t.Errorf("expected %v with layout %d to be %s but got %s", test.t, test.layout, test.expected, ver)
+		}
+	}
+}
+
+func TestNextAvailable(t *testing.T) {
+	tests := []struct {
+		base     string
+		bump     string
+		existing []string
+		expected string
+	}{
+		{"1.2.0", "minor", []string{"1.3.0"}, "1.4.0"},
+		{"1.2.0", "patch", []string{"1.2.1", "1.2.2+build"}, "1.2.3"},
+		{"1.2.0", "major", []string{"1.3.0"}, "2.0.0"},
+		{"1.2.0", "minor", []string{"1.3.0-rc.1"}, "1.3.0"},
+	}
+
+	for _, test := range tests {
+		next, err := NextAvailable(test.base, test.bump, test.existing)
+		if err != nil {
+			t.Error(err)
+		}
+		if next.String() != test.expected {
+			t.Errorf("expected next %s after %s avoiding %v to be %s but got %s", test.bump, test.base, test.existing, test.expected, next)
+		}
+	}
+
+	if _, err := NextAvailable("1.2.0", "build", nil); err == nil {
+		t.Error("expected an error for an unknown bump")
+	}
+}
+
+func TestProductionNext(t *testing.T) {
+	tests := []struct {
+		v        string
+		expected string
+	}{
+		{"1.2.0-rc.3", "1.2.0"},
+		{"1.2.0", "1.2.1"},
+		{"1.2.0-rc.3+build.9", "1.2.0"},
+		{"1.2.0+build.9", "1.2.1"},
+	}
+
+	for _, test := range tests {
+		ver, err := ParseVersion(test.v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		next, err := ver.ProductionNext()
+		if err != nil {
+			t.Error(err)
+		}
+		if next.String() != test.expected {
+			t.Errorf("expected production next of %s to be %s but got %s", test.v, test.expected, next)
+		}
+	}
+
+	if _, err := (Semver{Patch: math.MaxInt}).ProductionNext(); err == nil {
+		t.Error("expected an error for an overflowing patch")
+	}
+}
+
+func TestBumpReason(t *testing.T) {
+	tests := []struct {
+		from     string
+		to       string
+		expected string
+	}{
+		{"1.2.3", "2.0.0", "Major release: breaking changes"},
+		{"1.2.3", "1.3.0", "Minor release: new features"},
+		{"1.2.3", "1.2.4", "Patch release: bug fixes"},
+		{"1.2.3", "2.0.0-rc.1", "Prerelease: upcoming changes for testing"},
+		{"2.0.0-rc.1", "2.0.0-rc.2", "Prerelease: upcoming changes for testing"},
+		{"2.0.0-rc.2", "2.0.0", "Stable release: prerelease promoted"},
+		{"1.9.0-rc.1", "2.0.0", "Major release: breaking changes"},
+	}
+
+	for _, test := range tests {
+		reason, err := BumpReason(test.from, test.to)
+		if err != nil {
+			t.Error(err)
+		}
+		if reason != test.expected {
+			t.Errorf("expected %s to %s to be %q but got %q", test.from, test.to, test.expected, reason)
+		}
+	}
+
+	for _, pair := range [][2]string{{"1.2.3", "1.2.3+build"}, {"1.2.3", "1.2.2"}, {"1.2.3", "next"}} {
+		if _, err := BumpReason(pair[0], pair[1]); err == nil {
+			t.Errorf("expected an error for %s to %s", pair[0], pair[1])
+		}
+	}
+}
+
+func TestIsReleased(t *testing.T) {
+	tests := []struct {
+		v        string
+		expected bool
+	}{
+		{"1.2.3", true},
+		{"1.2.3+build", true},
+		{"1.2.3-rc.1", false},
+		{"1.2.3-rc.1+build", false},
+	}
+
+	for _, test := range tests {
+		ver, err := ParseVersion(test.v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result := ver.IsReleased(); result != test.expected {
+			t.Errorf("expected %s to be released=%t but got %t", test.v, test.expected, result)
+		}
+	}
+}
+
+func TestIsInitial(t *testing.T) {
+	tests := []struct {
+		v     string
+		minor bool
+		major bool
+	}{
+		{"1.3.0", true, false},
+		{"2.0.0", true, true},
+		{"1.3.1", false, false},
+		{"2.0.0-rc.1", false, false},
+		{"1.3.0+build", true, false},
+	}
+
+	for _, test := range tests {
+		ver, err := ParseVersion(test.v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ver.IsMinorInitial() != test.minor || ver.IsMajorInitial() != test.major {
+			t.Errorf("expected %s to be minor/major initial %t/%t but got %t/%t", test.v, test.minor, test.major, ver.IsMinorInitial(), ver.IsMajorInitial())
+		}
+	}
+}
+
+func TestApplyBumps(t *testing.T) {
+	tests := []struct {
+		base     string
+		bumps    []string
+		expected string
+	}{
+		{"1.0.0", []string{"patch", "minor", "major"}, "2.0.0"},
+		{"1.0.0", []string{"patch", "patch", "minor", "patch"}, "1.1.1"},
+		{"1.2.3-rc.1", nil, "1.2.3-rc.1"},
+	}
+
+	for _, test := range tests {
+		ver, err := ApplyBumps(test.base, test.bumps)
+		if err != nil {
+			t.Error(err)
+		}
+		if ver.String() != test.expected {
+			t.Errorf("expected %s after %v to be %s but got %s", test.base, test.bumps, test.expected, ver)
+		}
+	}
+
+	if _, err := ApplyBumps("1.0.0", []string{"patch", "huge"}); err == nil {
+		t.Error("expected an error for an unknown bump")
+	}
+}
+
+func TestPlanReleases(t *testing.T) {
+	tests := []struct {
+		start    string
+		count    int
+		cadence  string
+		expected string
+	}{
+		{"1.0.0", 5, "minor,patch", "[1.1.0 1.1.1 1.2.0 1.2.1 1.3.0]"},
+		{"1.0.0", 3, "patch", "[1.0.1 1.0.2 1.0.3]"},
+		{"v1.2.3-rc.1", 4, "minor, patch, patch", "[1.3.0 1.3.1 1.3.2 1.4.0]"},
+		{"1.0.0", 0, "major", "[]"},
+	}
+
+	for _, test := range tests {
+		plan, err := PlanReleases(test.start, test.count, test.cadence)
+		if err != nil {
+			t.Error(err)
+		}
+		if result := fmt.Sprint(plan); result != test.expected {
+			t.Errorf("expected %d releases from %s under %q to be %s but got %s", test.count, test.start, test.cadence, test.expected, result)
+		}
+	}
+
+	for _, cadence := range []string{"", "minor,,patch", "weekly"} {
+		if _, err := PlanReleases("1.0.0", 3, cadence); err == nil {
+			t.Errorf("expected an error for cadence %q", cadence)
+		}
+	}
+	if _, err := PlanReleases("1.0.0", -1, "patch"); err == nil {
+		t.Error("expected an error for a negative count")
+	}
+}
+
+func TestIncComponents(t *testing.T) {
+	tests := []struct {
+		v                   string
+		major, minor, patch string
+	}{
+		{"1.2.3", "2.0.0", "1.3.0", "1.2.4"},
+		{"1.2.3-rc.1+build.5", "2.0.0", "1.3.0", "1.2.4"},
+		{"0.0.0", "1.0.0", "0.1.0", "0.0.1"},
+	}
+
+	for _, test := range tests {
+		ver, err := ParseVersion(test.v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, c := range []struct {
+			name     string
+			inc      func() (Semver, error)
+			expected string
+		}{
+			{"IncMajor", ver.IncMajor, test.major},
+			{"IncMinor", ver.IncMinor, test.minor},
+			{"IncPatch", ver.IncPatch, test.patch},
+		} {
+			next, err := c.inc()
+			if err != nil {
+				t.Error(err)
+			}
+			if result := next.String(); result != c.expected {
+				t.Errorf("expected %s of %s to be %s but got %s", c.name, test.v, c.expected, result)
+			}
+		}
+	}
+
+	if _, err := (Semver{Major: math.MaxInt}).IncMajor(); err == nil {
+		t.Error("expected an error when the major version would overflow")
+	}
+}
+
+func TestBumpPrerelease(t *testing.T) {
+	tests := []struct {
+		v        string
+		expected string
+	}{
+		{"1.0.0-rc.1", "1.0.0-rc.2"},
+		{"1.0.0-beta.2.9", "1.0.0-beta.2.10"},
+		{"1.0.0-alpha", "1.0.0-alpha.1"},
+		{"1.0.0-0", "1.0.0-1"},
+		{"1.0.0-rc.1+build.7", "1.0.0-rc.2"},
+	}
+
+	for _, test := range tests {
+		ver, err := ParseVersion(test.v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		next, err := ver.BumpPrerelease()
+		if err != nil {
+			t.Error(err)
+		}
+		if result := next.String(); result != test.expected {
+			t.Errorf("expected %s bumped to be %s but got %s", test.v, test.expected, result)
+		}
+	}
+
+	if _, err := (Semver{Major: 1}).BumpPrerelease(); err == nil {
+		t.Error("expected an error for a version without a prerelease tag")
+	}
+	if _, err := (Semver{Major: 1, Prerelease: fmt.Sprint(math.MaxInt)}).BumpPrerelease(); err == nil {
+		t.Error("expected an error when the prerelease identifier would overflow")
+	}
+}
@@ -1,10 +1,11 @@
 package semver
 
 import (
+	"errors"
 	"fmt"
-	"regexp"
 	"strconv"
 	"strings"
+	"unicode"
 )
 
 type Semver struct {
@@ -15,7 +16,18 @@ type Semver struct {
 	Meta       string // x.x.x-x+001
 }
 
-var re = regexp.MustCompile(`\d+\.\d+\.\d+(-[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*)?(\+[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*)?`)
+// ErrEmptyComponent is returned when a major, minor, or patch component is empty, as in
+// "1..2" or "1.2.".
+var ErrEmptyComponent = errors.New("empty numeric component")
+
+// ErrNegativeComponent is returned when a major, minor, or patch component is negative, as
+// in "1.-1.0" or "-1.0.0".
+var ErrNegativeComponent = errors.New("negative numeric component")
+
+// ErrNonASCIIDigit is returned when a major, minor, or patch component contains a digit
+// outside of ASCII, such as the Arabic-Indic "٢", which could otherwise pass for a
+// version number in a homoglyph attack.
+var ErrNonASCIIDigit = errors.New("non-ASCII digit in numeric component")
 
 func compareInts(a, b int) int {
 	if a < b {
@@ -29,15 +41,15 @@ func compareInts(a, b int) int {
 // Compare takes two version strings, normalizes and parses them into Semver structures,
 // and then compares them according to the rules of semantic versioning.
 //
-// The function first compares the prerelease tags of the two versions. If both versions
-// have prerelease tags, it returns -1 if the tag of the first version is lexicographically
-// less than the tag of the second version, 1 if it's greater, and 0 if they're equal.
-// If only one version has a prerelease tag, that version is considered smaller.
+// The function first compares the major, minor, and patch versions in that order. For
+// each component, it returns -1 if the component of the first version is less than the
+// component of the second version, 1 if it's greater, and 0 if they're equal.
 //
-// If the prerelease tags are equal or nonexistent, the function compares the major, minor,
-// and patch versions in that order. For each component, it returns -1 if the component of
-// the first version is less than the component of the second version, 1 if it's greater,
-// and 0 if they're equal.
+// Only when all three components are equal are the prerelease tags compared. If both
+// versions have prerelease tags, they are compared identifier by identifier: numeric
+// identifiers numerically, alphanumeric identifiers lexicographically, and numeric
+// identifiers rank below alphanumeric ones. If only one version has a prerelease tag, that
+// version is considered smaller, so 2.0.0-alpha is greater than 1.0.0 but less than 2.0.0.
 //
 // If all components are equal, the function returns 0, indicating that the two versions
 // are equal.
@@ -52,58 +64,72 @@ func compareInts(a, b int) int {
 //	}
 //	fmt.Println(result) // prints -1
 func Compare(v1, v2 string) (int, error) {
-	ver1, err := ParseVersion(normalize(v1))
+	ver1, err := parse(v1)
 	if err != nil {
 		return 0, err
 	}
-	ver2, err := ParseVersion(normalize(v2))
+	ver2, err := parse(v2)
 	if err != nil {
 		return 0, err
 	}
+	return compare(ver1, ver2), nil
+}
+
+// compare orders two parsed versions using the same rules as Compare.
+func compare(ver1, ver2 Semver) int {
+	return compareBy(ver1, ver2, compareIdentifier)
+}
+
+// compareBy orders two parsed versions, using cmp to compare individual prerelease
+// identifiers.
+func compareBy(ver1, ver2 Semver, cmp func(a, b string) int) int {
+	if result := compareCore(ver1, ver2); result != 0 {
+		return result
+	}
 
-	// compare prerelease tag
+	// prerelease tags only break ties between equal cores
 	if ver1.Prerelease != "" && ver2.Prerelease != "" {
-		if ver1.Prerelease < ver2.Prerelease {
-			return -1, nil
-		} else if ver1.Prerelease > ver2.Prerelease {
-			return 1, nil
-		}
+		return comparePrerelease(ver1.Prerelease, ver2.Prerelease, cmp)
 	} else if ver1.Prerelease != "" {
-		return -1, nil
+		return -1
 	} else if ver2.Prerelease != "" {
-		return 1, nil
+		return 1
 	}
+	return 0
+}
 
+// compareCore compares only the major, minor, and patch components of two versions.
+func compareCore(ver1, ver2 Semver) int {
 	// compare version 1 major and version 2 major
 	if result := compareInts(ver1.Major, ver2.Major); result != 0 {
-		return result, nil
+		return result
 	}
 
 	// compare version 1 minor and version 2 minor
 	if result := compareInts(ver1.Minor, ver2.Minor); result != 0 {
-		return result, nil
+		return result
 	}
 
 	// compare version 1 pach and version 2 patch
-	if result := compareInts(ver1.Patch, ver2.Patch); result != 0 {
-		return result, nil
-	}
-
-	return 0, nil
+	return compareInts(ver1.Patch, ver2.Patch)
 }
 
 // ParseVersion takes a version string, normalizes it, and parses it into a Semver structure.
 //
 // The function first checks if the version string contains a "+" or a "-" character, which
 // indicate the presence of metadata or a prerelease tag, respectively. If a "+" is found,
-// the function splits the string at the "+" and assigns the second part to the Meta field
-// of the Semver structure. If a "-" is found, the function splits the string at the "-"
-// and assigns the second part to the Prerelease field of the Semver structure.
+// everything after the first "+" is assigned to the Meta field of the Semver structure.
+// If a "-" is found in what remains, everything after the first "-" is assigned to the
+// Prerelease field. Hyphens inside the prerelease tag or metadata are preserved, so
+// "1.0.0-alpha-beta+build-123" has the prerelease tag "alpha-beta" and the metadata
+// "build-123".
 //
 // After processing the metadata and prerelease tag, the function splits the remaining
 // version string at the "." characters to get the major, minor, and patch versions. These
 // are converted to integers and assigned to the Major, Minor, and Patch fields of the
-// Semver structure, respectively.
+// Semver structure, respectively. Components must be non-negative, so "1.-1.0" and
+// "-1.0.0" fail with ErrNegativeComponent rather than being read as a prerelease tag, and
+// must use ASCII digits only; other Unicode digits fail with ErrNonASCIIDigit.
 //
 // If there is an error parsing the version string, the function returns an empty Semver
 // structure and the error.
@@ -114,27 +140,12 @@ func Compare(v1, v2 string) (int, error) {
 //	if err != nil {
 //	    log.Fatal(err)
 //	}
-//	fmt.Println(ver) // prints {Major:1 Minor:0 Patch:0 Prerelease:alpha Meta:001}
+//	fmt.Println(ver) // prints 1.0.0-alpha+001
 func ParseVersion(v string) (Semver, error) {
-	var (
-		pre  string
-		meta string
-	)
-
-	if strings.Contains(v, "+") {
-		split := strings.Split(v, "+")
-		v = split[0]
-		if len(split) > 1 {
-			meta = split[1]
-		}
-	}
-
-	if strings.Contains(v, "-") {
-		split := strings.Split(v, "-")
-		v = split[0]
-		if len(split) > 1 {
-			pre = split[1]
-		}
+	v, meta, _ := strings.Cut(v, "+")
+	v, pre, _ := strings.Cut(v, "-")
+	if (v == "" || strings.HasSuffix(v, ".")) && pre != "" && isDigit(pre[0]) {
+		return Semver{}, fmt.Errorf("%w in %q", ErrNegativeComponent, v+"-"+pre)
 	}
 
 	major, minor, patch, err := splitVer(v)
@@ -151,19 +162,69 @@ func ParseVersion(v string) (Semver, error) {
 	}, nil
 }
 
-func splitVer(v string) (int, int, int, error) {
-	if strings.Contains(v, "+") {
-		v = strings.Split(v, "+")[0]
-	}
+// core returns s with its prerelease tag and metadata removed.
+func (s Semver) core() Semver {
+	return Semver{Major: s.Major, Minor: s.Minor, Patch: s.Patch}
+}
+
+// Compare compares s with other according to the rules of semantic versioning, like the
+// Compare function, without parsing. It returns -1 if s is lower than other, 1 if it is
+// higher, and 0 if they have equal precedence. Metadata is ignored.
+//
+// Example:
+//
+//	v1, _ := ParseVersion("1.0.0-alpha")
+//	v2, _ := ParseVersion("1.0.0")
+//	fmt.Println(v1.Compare(v2)) // prints -1
+func (s Semver) Compare(other Semver) int {
+	return compare(s, other)
+}
+
+// LessThan reports whether s has lower precedence than other.
+func (s Semver) LessThan(other Semver) bool {
+	return compare(s, other) < 0
+}
 
-	vers := make([]int, 3)
+// GreaterThan reports whether s has higher precedence than other.
+func (s Semver) GreaterThan(other Semver) bool {
+	return compare(s, other) > 0
+}
+
+// Equal reports whether s and other have equal precedence, so versions that differ only
+// in metadata are equal. Use == to compare every field.
+func (s Semver) Equal(other Semver) bool {
+	return compare(s, other) == 0
+}
+
+// key returns s with its metadata removed, so that versions of equal precedence have
+// equal keys.
+func (s Semver) key() Semver {
+	s.Meta = ""
+	return s
+}
 
-	split := strings.Split(v, ".")
-	if len(split) != 3 {
+func splitVer(v string) (int, int, int, error) {
+	v, _, _ = strings.Cut(v, "+")
+	if strings.Count(v, ".") != 2 {
 		return 0, 0, 0, fmt.Errorf("invalid semver format")
 	}
 
-	for i, s := range split {
+	var vers [3]int
+	rest := v
+	for i := range vers {
+		var s string
+		s, rest, _ = strings.Cut(rest, ".")
+		if s == "" {
+			return 0, 0, 0, fmt.Errorf("%w in %q", ErrEmptyComponent, v)
+		}
+		if s[0] == '-' {
+			return 0, 0, 0, fmt.Errorf("%w in %q", ErrNegativeComponent, v)
+		}
+		for _, r := range s {
+			if r > unicode.MaxASCII && unicode.IsDigit(r) {
+				return 0, 0, 0, fmt.Errorf("%w %q in %q", ErrNonASCIIDigit, r, v)
+			}
+		}
 		n, err := strconv.Atoi(s)
 		if err != nil {
 			return 0, 0, 0, err
@@ -174,7 +235,132 @@ func splitVer(v string) (int, int, int, error) {
 	return vers[0], vers[1], vers[2], nil
 }
 
+// normalize returns the first version found in v, or an empty string if there is none.
 func normalize(v string) string {
-	match := re.FindString(v)
-	return match
+	start, end := findVersion(v)
+	if start < 0 {
+		return ""
+	}
+	return v[start:end]
+}
+
+// findVersion returns the bounds of the first version in v, such as "1.2.3-rc.1+build"
+// in "release v1.2.3-rc.1+build", or -1, -1 if v contains none. A version is three
+// dot-separated runs of digits, optionally followed by a "-" and a prerelease tag and
+// by a "+" and metadata. The scan is done by hand rather than with a regular expression
+// because it runs for nearly every version the package parses.
+func findVersion(v string) (int, int) {
+	for start := 0; start < len(v); {
+		if !isDigit(v[start]) {
+			start++
+			continue
+		}
+		end, ok := scanCore(v, start)
+		if !ok {
+			// a version starting later in this run of digits would fail the same way
+			start = skipDigits(v, start)
+			continue
+		}
+		end = scanIdentifiers(v, end, '-')
+		end = scanIdentifiers(v, end, '+')
+		return start, end
+	}
+	return -1, -1
+}
+
+// scanCore scans the major, minor, and patch components starting at v[i] and returns
+// the index just past them.
+func scanCore(v string, i int) (int, bool) {
+	for n := 0; n < 3; n++ {
+		if n > 0 {
+			if i >= len(v) || v[i] != '.' {
+				return 0, false
+			}
+			i++
+		}
+		end := skipDigits(v, i)
+		if end == i {
+			return 0, false
+		}
+		i = end
+	}
+	return i, true
+}
+
+// scanIdentifiers scans the dot-separated identifiers introduced by sep at v[i], as in
+// "-rc.1" or "+build.5", and returns the index just past them, or i if there are none.
+// A trailing "." that is not followed by an identifier is left out.
+func scanIdentifiers(v string, i int, sep byte) int {
+	if i+1 >= len(v) || v[i] != sep || !isIdentChar(v[i+1]) {
+		return i
+	}
+	i = skipIdent(v, i+1)
+	for i+1 < len(v) && v[i] == '.' && isIdentChar(v[i+1]) {
+		i = skipIdent(v, i+1)
+	}
+	return i
+}
+
+// findDotted returns the first dotted triple in v whose numeric components may be
+// empty, such as "1..2", or an empty string if there is none.
+func findDotted(v string) string {
+	for start := range v {
+		i := skipDigits(v, start)
+		if i >= len(v) || v[i] != '.' {
+			continue
+		}
+		i = skipDigits(v, i+1)
+		if i >= len(v) || v[i] != '.' {
+			continue
+		}
+		return v[start:skipDigits(v, i+1)]
+	}
+	return ""
+}
+
+// skipDigits returns the index of the first non-digit in v at or after i.
+func skipDigits(v string, i int) int {
+	for i < len(v) && isDigit(v[i]) {
+		i++
+	}
+	return i
+}
+
+// skipIdent returns the index of the first byte in v at or after i that cannot appear
+// in an identifier.
+func skipIdent(v string, i int) int {
+	for i < len(v) && isIdentChar(v[i]) {
+		i++
+	}
+	return i
+}
+
+// isIdentChar reports whether c may appear in a prerelease or metadata identifier.
+func isIdentChar(c byte) bool {
+	return isDigit(c) || c == '-' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// parse normalizes v and parses it into a Semver structure.
+func parse(v string) (Semver, error) {
+	match := normalize(v)
+	if match == "" {
+		// report empty components in a malformed triple rather than a generic error
+		if dotted := findDotted(v); dotted != "" {
+			return ParseVersion(dotted)
+		}
+	}
+	return ParseVersion(match)
+}
+
+// parseAll parses every version in versions, stopping at the first error.
+func parseAll(versions []string) ([]Semver, error) {
+	parsed := make([]Semver, 0, len(versions))
+	for _, v := range versions {
+		ver, err := parse(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version %q: %w", v, err)
+		}
+		parsed = append(parsed, ver)
+	}
+	return parsed, nil
 }
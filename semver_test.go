@@ -1,6 +1,7 @@
 package semver
 
 import (
+	"errors"
 	"testing"
 )
 
@@ -25,6 +26,12 @@ func TestSemver(t *testing.T) {
 		{"1.0.0", "1.0.1", -1},
 		{"1.0.1", "1.0.0", 1},
 		{"1.0.0", "1.0.0", 0},
+		{"2.0.0-alpha", "1.0.0", 1},
+		{"1.0.0", "2.0.0-alpha", -1},
+		{"1.0.0-rc", "1.0.1", -1},
+		{"1.0.0-alpha", "2.0.0", -1},
+		{"1.1.0-alpha", "1.0.9", 1},
+		{"1.0.0-rc.1", "1.0.0", -1},
 	}
 
 	for _, test := range tests {
@@ -37,3 +44,184 @@ func TestSemver(t *testing.T) {
 		}
 	}
 }
+
+func TestSemverMethods(t *testing.T) {
+	tests := []struct {
+		v1       string
+		v2       string
+		expected int
+	}{
+		{"1.0.0-alpha", "1.0.0", -1},
+		{"1.10.0", "1.9.0", 1},
+		{"1.0.0+a", "1.0.0+b", 0},
+	}
+
+	for _, test := range tests {
+		ver1, err := ParseVersion(test.v1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ver2, err := ParseVersion(test.v2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c := ver1.Compare(ver2); c != test.expected {
+			t.Errorf("expected %s.Compare(%s) to be %d but got %d", test.v1, test.v2, test.expected, c)
+		}
+		if ver1.LessThan(ver2) != (test.expected < 0) || ver1.GreaterThan(ver2) != (test.expected > 0) || ver1.Equal(ver2) != (test.expected == 0) {
+			t.Errorf("expected the comparison methods of %s and %s to agree with %d", test.v1, test.v2, test.expected)
+		}
+	}
+}
+
+func TestEmptyComponent(t *testing.T) {
+	inputs := []string{"1..2", "1.2.", ".1.2", ".."}
+
+	for _, input := range inputs {
+		if _, err := ParseVersion(input); !errors.Is(err, ErrEmptyComponent) {
+			t.Errorf("expected ParseVersion(%q) to fail with %v but got %v", input, ErrEmptyComponent, err)
+		}
+	}
+
+	for _, input := range append(inputs, "v1..3-rc.1", "release 2..1") {
+		if _, err := Compare(input, "1.0.0"); !errors.Is(err, ErrEmptyComponent) {
+			t.Errorf("expected Compare(%q) to fail with %v but got %v", input, ErrEmptyComponent, err)
+		}
+		if _, err := Canonical(input); !errors.Is(err, ErrEmptyComponent) {
+			t.Errorf("expected Canonical(%q) to fail with %v but got %v", input, ErrEmptyComponent, err)
+		}
+	}
+
+	for _, input := range []string{">=1.", "^1..2", "~.2"} {
+		if _, err := ParseConstraint(input); !errors.Is(err, ErrEmptyComponent) {
+			t.Errorf("expected ParseConstraint(%q) to fail with %v but got %v", input, ErrEmptyComponent, err)
+		}
+	}
+}
+
+func TestNegativeComponent(t *testing.T) {
+	for _, input := range []string{"1.-1.0", "-1.0.0", "1.0.-1", "-1.0.0-rc.1"} {
+		if _, err := ParseVersion(input); !errors.Is(err, ErrNegativeComponent) {
+			t.Errorf("expected ParseVersion(%q) to fail with %v but got %v", input, ErrNegativeComponent, err)
+		}
+	}
+
+	// a leading hyphen in a prerelease identifier is not a negative number
+	ver, err := ParseVersion("1.0.0--1")
+	if err != nil {
+		t.Error(err)
+	}
+	if ver.Prerelease != "-1" {
+		t.Errorf("expected prerelease -1 but got %q", ver.Prerelease)
+	}
+}
+
+func TestNonASCIIDigit(t *testing.T) {
+	// Arabic-Indic two, fullwidth three, and Devanagari one
+	for _, input := range []string{"1.\u0662.3", "1.2.\uff13", "\u0967.0.0", "1.2\u0662.3"} {
+		if _, err := ParseVersion(input); !errors.Is(err, ErrNonASCIIDigit) {
+			t.Errorf("expected ParseVersion(%q) to fail with %v but got %v", input, ErrNonASCIIDigit, err)
+		}
+	}
+}
+
+func TestPrereleasePrecedenceChain(t *testing.T) {
+	// the example chain from https://semver.org/#spec-item-11
+	chain := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+	}
+
+	for i := range chain {
+		for j := range chain {
+			expected := compareInts(i, j)
+			c, err := Compare(chain[i], chain[j])
+			if err != nil {
+				t.Fatal(err)
+			}
+			if c != expected {
+				t.Errorf("expected %s and %s to be %d but got %d", chain[i], chain[j], expected, c)
+			}
+		}
+	}
+
+	if c, _ := Compare("1.0.0-alpha.2", "1.0.0-alpha.10"); c != -1 {
+		t.Errorf("expected numeric identifiers to compare numerically but got %d", c)
+	}
+}
+
+func TestParseVersionHyphens(t *testing.T) {
+	tests := []struct {
+		v    string
+		pre  string
+		meta string
+	}{
+		{"1.0.0-alpha-beta", "alpha-beta", ""},
+		{"1.0.0+build-123", "", "build-123"},
+		{"1.0.0-alpha-beta+build-123", "alpha-beta", "build-123"},
+		{"1.0.0-rc.1+exp.sha-5114f85.x-y", "rc.1", "exp.sha-5114f85.x-y"},
+		{"1.0.0-x+y+z", "x", "y+z"},
+	}
+
+	for _, test := range tests {
+		ver, err := ParseVersion(test.v)
+		if err != nil {
+			t.Error(err)
+		}
+		if ver.Prerelease != test.pre || ver.Meta != test.meta {
+			t.Errorf("expected %s to have prerelease %q and metadata %q but got %q and %q", test.v, test.pre, test.meta, ver.Prerelease, ver.Meta)
+		}
+		if ver.Major != 1 || ver.Minor != 0 || ver.Patch != 0 {
+			t.Errorf("expected %s to have core 1.0.0 but got %+v", test.v, ver)
+		}
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		v        string
+		expected string
+	}{
+		{"1.2.3", "1.2.3"},
+		{"v1.2.3", "1.2.3"},
+		{"release-1.2.3-rc.1+build.5 final", "1.2.3-rc.1+build.5"},
+		{"1.2.3.4", "1.2.3"},
+		{"1.2.3-", "1.2.3"},
+		{"1.2.3-rc.", "1.2.3-rc"},
+		{"1.2.3+.x", "1.2.3"},
+		{"12x1.2.3", "1.2.3"},
+		{"1.2.x 4.5.6", "4.5.6"},
+		{"1..2", ""},
+		{"latest", ""},
+	}
+
+	for _, test := range tests {
+		if result := normalize(test.v); result != test.expected {
+			t.Errorf("expected %q to normalize to %q but got %q", test.v, test.expected, result)
+		}
+	}
+}
+
+func TestParseAllocs(t *testing.T) {
+	allocs := testing.AllocsPerRun(100, func() {
+		v1, _ := parse("v1.2.3-rc.1+build.5")
+		v2, _ := parse("1.2.3-rc.beta")
+		_ = compare(v1, v2)
+	})
+	if allocs != 0 {
+		t.Errorf("expected parsing and comparing to allocate nothing but got %v allocations", allocs)
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = parse("v1.2.3-rc.1+build.5")
+	}
+}
@@ -0,0 +1,504 @@
+package semver
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// byVersion sorts version strings in ascending order of precedence using their parsed
+// counterparts. The raw and vers slices must be the same length and index-aligned.
+type byVersion struct {
+	raw  []string
+	vers []Semver
+}
+
+func (b byVersion) Len() int { return len(b.raw) }
+
+func (b byVersion) Less(i, j int) bool { return compare(b.vers[i], b.vers[j]) < 0 }
+
+func (b byVersion) Swap(i, j int) {
+	b.raw[i], b.raw[j] = b.raw[j], b.raw[i]
+	b.vers[i], b.vers[j] = b.vers[j], b.vers[i]
+}
+
+// Max returns the version with the highest precedence from a list of version strings.
+//
+// The original string is returned, so prefixes and metadata are preserved. When several
+// versions share the highest precedence, the first of them is returned.
+//
+// If the list is empty or any version cannot be parsed, the function returns an empty
+// string and an error.
+//
+// Example:
+//
+//	latest, err := Max([]string{"1.2.0", "v1.10.0", "1.9.3"})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(latest) // prints v1.10.0
+func Max(versions []string) (string, error) {
+	if len(versions) == 0 {
+		return "", fmt.Errorf("no versions given")
+	}
+	vers, err := parseAll(versions)
+	if err != nil {
+		return "", err
+	}
+
+	return versions[extreme(vers, 1)], nil
+}
+
+// Min returns the version with the lowest precedence from a list of version strings.
+//
+// The original string is returned, so prefixes and metadata are preserved. When several
+// versions share the lowest precedence, the first of them is returned.
+//
+// If the list is empty or any version cannot be parsed, the function returns an empty
+// string and an error.
+//
+// Example:
+//
+//	oldest, err := Min([]string{"1.2.0", "v1.10.0", "1.9.3"})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(oldest) // prints 1.2.0
+func Min(versions []string) (string, error) {
+	if len(versions) == 0 {
+		return "", fmt.Errorf("no versions given")
+	}
+	vers, err := parseAll(versions)
+	if err != nil {
+		return "", err
+	}
+
+	return versions[extreme(vers, -1)], nil
+}
+
+// MaxExcluding returns the highest version from a list that is not in the bad list,
+// answering "what is the latest good version".
+//
+// Versions are matched against bad by precedence, so prefixes and metadata are ignored:
+// "v1.2.3+b" is excluded when bad holds "1.2.3". The original string is returned.
+//
+// If any version cannot be parsed, or every version is bad, the function returns an empty
+// string and an error.
+//
+// Example:
+//
+//	latest, err := MaxExcluding([]string{"1.2.0", "1.3.0", "1.2.5"}, []string{"1.3.0"})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(latest) // prints 1.2.5
+func MaxExcluding(versions []string, bad []string) (string, error) {
+	vers, err := parseAll(versions)
+	if err != nil {
+		return "", err
+	}
+	badVers, err := parseAll(bad)
+	if err != nil {
+		return "", err
+	}
+
+	excluded := make(map[Semver]bool, len(badVers))
+	for _, ver := range badVers {
+		excluded[ver.key()] = true
+	}
+
+	best := -1
+	for i, ver := range vers {
+		if excluded[ver.key()] {
+			continue
+		}
+		if best < 0 || compare(ver, vers[best]) > 0 {
+			best = i
+		}
+	}
+	if best < 0 {
+		return "", fmt.Errorf("no good versions given")
+	}
+	return versions[best], nil
+}
+
+// extreme returns the index of the first version in vers that compares as sign against
+// every other version: 1 picks the highest and -1 the lowest. vers must not be empty.
+func extreme(vers []Semver, sign int) int {
+	best := 0
+	for i := 1; i < len(vers); i++ {
+		if compare(vers[i], vers[best]) == sign {
+			best = i
+		}
+	}
+	return best
+}
+
+// SortStrings returns a copy of versions sorted in ascending order of precedence, leaving
+// the input unchanged.
+//
+// Every version is parsed once before sorting. Versions of equal precedence, such as
+// those differing only in metadata, keep their original relative order, and prereleases
+// sort before the release they precede.
+//
+// If any version cannot be parsed, the function returns nil and the error.
+//
+// Example:
+//
+//	sorted, err := SortStrings([]string{"v1.10.0", "1.2.0", "1.10.0-rc.1"})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(sorted) // prints [1.2.0 1.10.0-rc.1 v1.10.0]
+func SortStrings(versions []string) ([]string, error) {
+	vers, err := parseAll(versions)
+	if err != nil {
+		return nil, err
+	}
+
+	sorted := make([]string, len(versions))
+	copy(sorted, versions)
+	sort.Stable(byVersion{sorted, vers})
+	return sorted, nil
+}
+
+// Sort sorts parsed versions in place in ascending order of precedence. Versions of
+// equal precedence, such as those differing only in metadata, keep their original
+// relative order. Use SortStrings to sort version strings.
+//
+// Example:
+//
+//	vers := []Semver{{Major: 1, Minor: 10}, {Major: 1, Minor: 2}}
+//	Sort(vers)
+//	fmt.Println(vers) // prints [1.2.0 1.10.0]
+func Sort(vers []Semver) {
+	sort.Stable(Versions(vers))
+}
+
+// IsForwardOnly reports whether a sequence of versions, taken in the given order, never
+// decreases in precedence, as expected of an append-only release log. Equal consecutive
+// entries are allowed. When the sequence does decrease, the function also returns the
+// index of the first version lower than its predecessor; otherwise the index is -1.
+//
+// If any version cannot be parsed, the function returns false, -1, and the error.
+//
+// Example:
+//
+//	ok, at, err := IsForwardOnly([]string{"1.0.0", "1.1.0", "1.0.5", "1.2.0"})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(ok, at) // prints false 2
+func IsForwardOnly(versions []string) (bool, int, error) {
+	vers, err := parseAll(versions)
+	if err != nil {
+		return false, -1, err
+	}
+
+	for i := 1; i < len(vers); i++ {
+		if compare(vers[i], vers[i-1]) < 0 {
+			return false, i, nil
+		}
+	}
+	return true, -1, nil
+}
+
+// OrderBuilds returns a copy of versions sorted for listing build artifacts: by
+// precedence first and by metadata second, so several builds of 1.0.0 order by their
+// build timestamp or number.
+//
+// This is a superset of the spec ordering, which ignores metadata. Metadata is compared
+// identifier by identifier like a prerelease tag: numeric identifiers numerically, others
+// lexically, and versions without metadata first.
+//
+// If any version cannot be parsed, the function returns nil and the error.
+//
+// Example:
+//
+//	builds, err := OrderBuilds([]string{"1.0.0+20230102", "1.0.0+20230101", "0.9.0+20230105"})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(builds) // prints [0.9.0+20230105 1.0.0+20230101 1.0.0+20230102]
+func OrderBuilds(versions []string) ([]string, error) {
+	vers, err := parseAll(versions)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := CompareOptions{
+		MetaTiebreak: true,
+		MetaComparator: func(a, b string) int {
+			return comparePrerelease(a, b, compareIdentifier)
+		},
+	}
+
+	sorted := make([]string, len(versions))
+	copy(sorted, versions)
+	sort.Stable(byOptions{byVersion{sorted, vers}, opts})
+	return sorted, nil
+}
+
+// byOptions sorts like byVersion, ordering versions with CompareOptions.
+type byOptions struct {
+	byVersion
+	opts CompareOptions
+}
+
+func (b byOptions) Less(i, j int) bool { return b.opts.compare(b.vers[i], b.vers[j]) < 0 }
+
+// SortByVersion sorts items in place in ascending order of the version returned by key.
+//
+// Each key is extracted and parsed once before sorting, and items with versions of equal
+// precedence keep their original order.
+//
+// If any key cannot be parsed, the function returns an error and items is left
+// unchanged.
+//
+// Example:
+//
+//	type release struct{ Name, Version string }
+//	releases := []release{{"b", "1.10.0"}, {"a", "1.2.0"}}
+//	err := SortByVersion(releases, func(r release) string { return r.Version })
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(releases[0].Name) // prints a
+func SortByVersion[T any](items []T, key func(T) string) error {
+	vers := make([]Semver, len(items))
+	for i, item := range items {
+		k := key(item)
+		ver, err := parse(k)
+		if err != nil {
+			return fmt.Errorf("invalid version %q: %w", k, err)
+		}
+		vers[i] = ver
+	}
+
+	sort.Stable(byKey[T]{items, vers})
+	return nil
+}
+
+// byKey sorts items by their index-aligned parsed versions.
+type byKey[T any] struct {
+	items []T
+	vers  []Semver
+}
+
+func (b byKey[T]) Len() int { return len(b.items) }
+
+func (b byKey[T]) Less(i, j int) bool { return compare(b.vers[i], b.vers[j]) < 0 }
+
+func (b byKey[T]) Swap(i, j int) {
+	b.items[i], b.items[j] = b.items[j], b.items[i]
+	b.vers[i], b.vers[j] = b.vers[j], b.vers[i]
+}
+
+// Versions attaches the methods of sort.Interface to a slice of parsed versions, sorting
+// in ascending order of precedence.
+//
+// Example:
+//
+//	vs := Versions{{Major: 1, Minor: 10}, {Major: 1, Minor: 2}}
+//	sort.Sort(vs)
+//	fmt.Println(vs[0]) // prints 1.2.0
+type Versions []Semver
+
+// VersionSlice and Collection are alternative names for Versions, kept for existing
+// callers.
+type (
+	VersionSlice = Versions
+	Collection   = Versions
+)
+
+func (vs Versions) Len() int { return len(vs) }
+
+func (vs Versions) Less(i, j int) bool { return compare(vs[i], vs[j]) < 0 }
+
+func (vs Versions) Swap(i, j int) { vs[i], vs[j] = vs[j], vs[i] }
+
+// Order is a precomputed ordering of a fixed set of versions, so that repeated
+// comparisons between them reduce to comparing integer ranks. Build one with NewOrder.
+type Order struct {
+	raw  map[string]int
+	keys map[Semver]int
+}
+
+// NewOrder sorts a fixed set of versions once and returns their Order.
+//
+// Ranks start at 0 for the lowest version. Versions of equal precedence, such as those
+// differing only in a prefix or metadata, share a rank.
+//
+// If any version cannot be parsed, the function returns nil and the error.
+//
+// Example:
+//
+//	order, err := NewOrder([]string{"1.10.0", "1.2.0", "1.9.3"})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	rank, _ := order.Rank("1.9.3")
+//	fmt.Println(rank) // prints 1
+func NewOrder(versions []string) (*Order, error) {
+	vers, err := parseAll(versions)
+	if err != nil {
+		return nil, err
+	}
+
+	sorted := make([]Semver, len(vers))
+	for i, ver := range vers {
+		sorted[i] = ver.key()
+	}
+	sort.Sort(Versions(sorted))
+
+	o := &Order{raw: make(map[string]int, len(versions)), keys: make(map[Semver]int, len(sorted))}
+	rank := -1
+	for i, ver := range sorted {
+		if i == 0 || compare(sorted[i-1], ver) != 0 {
+			rank++
+		}
+		o.keys[ver] = rank
+	}
+	for i, v := range versions {
+		o.raw[v] = o.keys[vers[i].key()]
+	}
+
+	return o, nil
+}
+
+// Rank returns the rank of v within the order and reports whether v belongs to it. A
+// version spelled exactly as it was given to NewOrder is found without parsing; any other
+// spelling of a version of equal precedence is parsed and matched as well.
+func (o *Order) Rank(v string) (int, bool) {
+	if rank, ok := o.raw[v]; ok {
+		return rank, true
+	}
+	ver, err := parse(v)
+	if err != nil {
+		return 0, false
+	}
+	rank, ok := o.keys[ver.key()]
+	return rank, ok
+}
+
+// AgeRank returns the zero-based rank of v among all counted from the newest version, so
+// the latest release has rank 0 and the one before it rank 1. This drives labels such as
+// "latest" and "one behind". Versions of equal precedence, such as those differing only
+// in a prefix or metadata, count once and share a rank.
+//
+// If v or any version in all cannot be parsed, or v is not in all, the function returns
+// 0 and an error.
+//
+// Example:
+//
+//	rank, err := AgeRank("1.9.0", []string{"1.2.0", "1.10.0", "1.9.0"})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(rank) // prints 1
+func AgeRank(v string, all []string) (int, error) {
+	target, err := parse(v)
+	if err != nil {
+		return 0, err
+	}
+	vers, err := parseAll(all)
+	if err != nil {
+		return 0, err
+	}
+
+	sorted := uniqueSorted(vers)
+	for i := len(sorted) - 1; i >= 0; i-- {
+		if sorted[i] == target.key() {
+			return len(sorted) - 1 - i, nil
+		}
+	}
+	return 0, fmt.Errorf("version %q not found", v)
+}
+
+// Ordinal encodes s as a pair of sortable integers for storage systems that can only
+// order by plain columns, such as SQL ORDER BY core, pre. Sorting by the first value and
+// then the second reproduces the precedence of Compare within the limits below.
+//
+// The first value packs the major, minor, and patch components into 21 bits each;
+// larger components saturate at 2097151. The second value is math.MaxInt64 for a
+// release, so it sorts after every prerelease of the same core. For a prerelease it packs
+// the first three identifiers into 20 bits each, with absent identifiers lowest, numeric
+// identifiers (saturating at 524286) next, and alphanumeric identifiers highest, ordered
+// by their first three characters. Prereleases that differ only beyond those limits
+// encode equally. Metadata is ignored.
+//
+// Example:
+//
+//	ver, _ := ParseVersion("1.2.3-rc.1")
+//	core, pre := ver.Ordinal()
+//	fmt.Println(core, pre) // prints 4398050705411 826973481578004480
+func (s Semver) Ordinal() (int64, int64) {
+	const componentMask = 1<<21 - 1
+	core := saturate(s.Major, componentMask)<<42 | saturate(s.Minor, componentMask)<<21 | saturate(s.Patch, componentMask)
+
+	if s.Prerelease == "" {
+		return core, math.MaxInt64
+	}
+
+	var pre int64
+	idents := strings.Split(s.Prerelease, ".")
+	for i := 0; i < 3; i++ {
+		pre <<= 20
+		if i < len(idents) {
+			pre |= identifierOrdinal(idents[i])
+		}
+	}
+	return core, pre
+}
+
+// identifierOrdinal encodes a prerelease identifier into 20 bits as described by
+// Ordinal: 0 is reserved for an absent identifier, numeric identifiers use the lower half,
+// and alphanumeric identifiers the upper half.
+func identifierOrdinal(ident string) int64 {
+	const half = 1 << 19
+	if isNumeric(ident) {
+		n, err := strconv.ParseInt(ident, 10, 64)
+		if err != nil || n > half-2 {
+			n = half - 2
+		}
+		return 1 + n
+	}
+
+	var code int64
+	for i := 0; i < 3; i++ {
+		code <<= 6
+		if i < len(ident) {
+			code |= charOrdinal(ident[i])
+		}
+	}
+	return half + code
+}
+
+// charOrdinal maps an identifier character to 6 bits, preserving ASCII order and
+// reserving 0 for padding.
+func charOrdinal(c byte) int64 {
+	switch {
+	case c == '-':
+		return 1
+	case c >= '0' && c <= '9':
+		return 2 + int64(c-'0')
+	case c >= 'A' && c <= 'Z':
+		return 12 + int64(c-'A')
+	case c >= 'a' && c <= 'z':
+		return 38 + int64(c-'a')
+	}
+	return 0
+}
+
+// saturate returns n as an int64 clamped to the range 0 to limit.
+func saturate(n int, limit int64) int64 {
+	switch {
+	case n < 0:
+		return 0
+	case int64(n) > limit:
+		return limit
+	}
+	return int64(n)
+}
@@ -0,0 +1,393 @@
+package semver
+
+import (
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestMax(t *testing.T) {
+	tests := []struct {
+		versions []string
+		expected string
+	}{
+		{[]string{"1.2.0", "v1.10.0", "1.9.3"}, "v1.10.0"},
+		{[]string{"1.0.0-rc.1", "1.0.0-beta.2"}, "1.0.0-rc.1"},
+		{[]string{"1.0.0+a", "1.0.0+b"}, "1.0.0+a"},
+	}
+
+	for _, test := range tests {
+		latest, err := Max(test.versions)
+		if err != nil {
+			t.Error(err)
+		}
+		if latest != test.expected {
+			t.Errorf("expected max of %v to be %s but got %s", test.versions, test.expected, latest)
+		}
+	}
+
+	if _, err := Max(nil); err == nil {
+		t.Error("expected an error for an empty list")
+	}
+}
+
+func TestMin(t *testing.T) {
+	tests := []struct {
+		versions []string
+		expected string
+	}{
+		{[]string{"1.10.0", "v1.2.0", "1.9.3"}, "v1.2.0"},
+		{[]string{"1.0.0", "1.0.0-rc.1", "0.9.9"}, "0.9.9"},
+		{[]string{"1.0.0+a", "1.0.0+b"}, "1.0.0+a"},
+	}
+
+	for _, test := range tests {
+		oldest, err := Min(test.versions)
+		if err != nil {
+			t.Error(err)
+		}
+		if oldest != test.expected {
+			t.Errorf("expected min of %v to be %s but got %s", test.versions, test.expected, oldest)
+		}
+	}
+
+	if _, err := Min(nil); err == nil {
+		t.Error("expected an error for an empty list")
+	}
+}
+
+func TestMaxExcluding(t *testing.T) {
+	tests := []struct {
+		versions []string
+		bad      []string
+		expected string
+	}{
+		{[]string{"1.2.0", "1.3.0", "1.2.5"}, []string{"1.3.0"}, "1.2.5"},
+		{[]string{"1.2.0", "v1.3.0+build", "1.2.5"}, []string{"1.3.0"}, "1.2.5"},
+		{[]string{"1.2.0", "1.3.0", "1.2.5"}, []string{"1.3.0", "1.2.5"}, "1.2.0"},
+		{[]string{"1.2.0", "1.3.0"}, nil, "1.3.0"},
+		{[]string{"1.2.0", "1.3.0-rc.1"}, []string{"1.3.0"}, "1.3.0-rc.1"},
+	}
+
+	for _, test := range tests {
+		latest, err := MaxExcluding(test.versions, test.bad)
+		if err != nil {
+			t.Error(err)
+		}
+		if latest != test.expected {
+			t.Errorf("expected max of %v excluding %v to be %s but got %s", test.versions, test.bad, test.expected, latest)
+		}
+	}
+
+	if _, err := MaxExcluding([]string{"1.3.0"}, []string{"1.3.0"}); err == nil {
+		t.Error("expected an error when every version is bad")
+	}
+	if _, err := MaxExcluding([]string{"1.3.0"}, []string{"bad"}); err == nil {
+		t.Error("expected an error for an invalid bad version")
+	}
+}
+
+func TestSortStrings(t *testing.T) {
+	tests := []struct {
+		versions []string
+		expected []string
+	}{
+		{[]string{"v1.10.0", "1.2.0", "1.10.0-rc.1"}, []string{"1.2.0", "1.10.0-rc.1", "v1.10.0"}},
+		{[]string{"1.0.0+b", "1.0.0+a", "0.9.0+z"}, []string{"0.9.0+z", "1.0.0+b", "1.0.0+a"}},
+		{[]string{"2.0.0", "2.0.0-rc.1", "2.0.0-alpha"}, []string{"2.0.0-alpha", "2.0.0-rc.1", "2.0.0"}},
+		{[]string{}, []string{}},
+	}
+
+	for _, test := range tests {
+		input := append([]string{}, test.versions...)
+		sorted, err := SortStrings(input)
+		if err != nil {
+			t.Error(err)
+		}
+		if strings.Join(sorted, " ") != strings.Join(test.expected, " ") {
+			t.Errorf("expected %v to sort as %v but got %v", test.versions, test.expected, sorted)
+		}
+		if strings.Join(input, " ") != strings.Join(test.versions, " ") {
+			t.Errorf("expected the input %v to be left unchanged but got %v", test.versions, input)
+		}
+	}
+
+	if _, err := SortStrings([]string{"1.0.0", "latest"}); err == nil {
+		t.Error("expected an error for an invalid version")
+	}
+}
+
+func TestSort(t *testing.T) {
+	vers := []Semver{
+		{Major: 1, Minor: 10},
+		{Major: 1, Minor: 2, Meta: "b"},
+		{Major: 1, Minor: 10, Prerelease: "rc.1"},
+		{Major: 1, Minor: 2, Meta: "a"},
+	}
+	Sort(vers)
+
+	expected := []string{"1.2.0+b", "1.2.0+a", "1.10.0-rc.1", "1.10.0"}
+	for i, ver := range vers {
+		if ver.String() != expected[i] {
+			t.Errorf("expected %v but got %v", expected, vers)
+			break
+		}
+	}
+
+	vs := Versions{{Major: 2}, {Major: 1}}
+	sort.Sort(vs)
+	if vs[0].Major != 1 {
+		t.Errorf("expected Versions to sort ascending but got %v", vs)
+	}
+}
+
+func TestIsForwardOnly(t *testing.T) {
+	tests := []struct {
+		versions []string
+		ok       bool
+		at       int
+	}{
+		{[]string{"1.0.0", "1.0.0+build", "1.1.0-rc.1", "1.1.0", "2.0.0"}, true, -1},
+		{[]string{"1.0.0", "1.1.0", "1.0.5", "1.2.0", "0.9.0"}, false, 2},
+		{[]string{"1.1.0", "1.1.0-rc.1"}, false, 1},
+		{[]string{}, true, -1},
+	}
+
+	for _, test := range tests {
+		ok, at, err := IsForwardOnly(test.versions)
+		if err != nil {
+			t.Error(err)
+		}
+		if ok != test.ok || at != test.at {
+			t.Errorf("expected %v to give %v %d but got %v %d", test.versions, test.ok, test.at, ok, at)
+		}
+	}
+
+	if _, _, err := IsForwardOnly([]string{"1.0.0", "oops"}); err == nil {
+		t.Error("expected an error for an invalid version")
+	}
+}
+
+func TestOrderBuilds(t *testing.T) {
+	tests := []struct {
+		versions []string
+		expected []string
+	}{
+		{[]string{"1.0.0+20230102", "1.0.0+20230101"}, []string{"1.0.0+20230101", "1.0.0+20230102"}},
+		{[]string{"1.0.0+b.10", "1.0.0+b.9", "1.0.0", "0.9.0+b.99"}, []string{"0.9.0+b.99", "1.0.0", "1.0.0+b.9", "1.0.0+b.10"}},
+		{[]string{"1.0.0+zeta", "1.0.0+alpha", "1.0.0+42"}, []string{"1.0.0+42", "1.0.0+alpha", "1.0.0+zeta"}},
+	}
+
+	for _, test := range tests {
+		builds, err := OrderBuilds(test.versions)
+		if err != nil {
+			t.Error(err)
+		}
+		if strings.Join(builds, " ") != strings.Join(test.expected, " ") {
+			t.Errorf("expected %v to order as %v but got %v", test.versions, test.expected, builds)
+		}
+	}
+
+	if _, err := OrderBuilds([]string{"1.0.0+1", "nope"}); err == nil {
+		t.Error("expected an error for an invalid version")
+	}
+}
+
+func TestSortByVersion(t *testing.T) {
+	type release struct {
+		Name    string
+		Version string
+	}
+
+	releases := []release{
+		{"c", "1.10.0"},
+		{"a", "v1.2.0"},
+		{"d", "2.0.0"},
+		{"b", "1.2.0+build"},
+	}
+	if err := SortByVersion(releases, func(r release) string { return r.Version }); err != nil {
+		t.Fatal(err)
+	}
+
+	var names string
+	for _, r := range releases {
+		names += r.Name
+	}
+	if names != "abcd" {
+		t.Errorf("expected order abcd but got %s", names)
+	}
+
+	bad := []release{{"a", "1.0.0"}, {"b", "latest"}}
+	if err := SortByVersion(bad, func(r release) string { return r.Version }); err == nil {
+		t.Error("expected an error for an invalid version")
+	}
+	if bad[0].Name != "a" {
+		t.Error("expected items to be left unchanged on error")
+	}
+}
+
+func TestVersionSlice(t *testing.T) {
+	raw := []string{"1.0.0", "1.0.1-alpha", "1.0.0-rc.1", "1.0.0-beta.11", "0.9.0", "1.0.0-alpha", "1.0.0-beta.2", "1.0.0-alpha.1"}
+	vs := make(VersionSlice, len(raw))
+	for i, v := range raw {
+		ver, err := ParseVersion(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		vs[i] = ver
+	}
+
+	sort.Sort(vs)
+
+	expected := []string{"0.9.0", "1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "1.0.1-alpha"}
+	for i, v := range vs {
+		if v.String() != expected[i] {
+			t.Errorf("expected %s at index %d but got %s", expected[i], i, v)
+		}
+	}
+}
+
+func TestCollection(t *testing.T) {
+	c := Collection{
+		{Major: 1, Meta: "b"},
+		{Major: 1, Prerelease: "rc.1"},
+		{Major: 1, Meta: "a"},
+		{Minor: 9},
+	}
+	sort.Stable(c)
+
+	expected := []string{"0.9.0", "1.0.0-rc.1", "1.0.0+b", "1.0.0+a"}
+	for i, v := range c {
+		if v.String() != expected[i] {
+			t.Errorf("expected %s at index %d but got %s", expected[i], i, v)
+		}
+	}
+}
+
+func TestOrder(t *testing.T) {
+	versions := []string{"1.10.0", "v1.2.0", "1.9.3", "1.9.3+build", "2.0.0-rc.1", "1.0.0-alpha", "2.0.0"}
+	order, err := NewOrder(versions)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, a := range versions {
+		for _, b := range versions {
+			expected, err := Compare(a, b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ra, okA := order.Rank(a)
+			rb, okB := order.Rank(b)
+			if !okA || !okB {
+				t.Fatalf("expected %s and %s to be ranked", a, b)
+			}
+			if result := compareInts(ra, rb); result != expected {
+				t.Errorf("expected ranks of %s and %s to compare as %d but got %d", a, b, expected, result)
+			}
+		}
+	}
+
+	if rank, ok := order.Rank("1.2.0"); !ok || rank != 1 {
+		t.Errorf("expected 1.2.0 to have rank 1 but got %d %v", rank, ok)
+	}
+	if _, ok := order.Rank("3.0.0"); ok {
+		t.Error("expected 3.0.0 not to be ranked")
+	}
+	if _, err := NewOrder([]string{"latest"}); err == nil {
+		t.Error("expected an error for an invalid version")
+	}
+}
+
+func BenchmarkOrderRank(b *testing.B) {
+	order, err := NewOrder([]string{"1.0.0", "1.1.0", "1.2.0", "1.2.1", "2.0.0-rc.1", "2.0.0"})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ra, _ := order.Rank("1.2.1")
+		rb, _ := order.Rank("2.0.0-rc.1")
+		_ = ra < rb
+	}
+}
+
+func BenchmarkCompare(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = Compare("1.2.1", "2.0.0-rc.1")
+	}
+}
+
+func TestOrdinal(t *testing.T) {
+	chain := []string{
+		"0.9.9",
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.1-0",
+		"1.0.1",
+		"1.2.0",
+		"2.0.0",
+	}
+
+	type ordinal struct{ core, pre int64 }
+	ordinals := make([]ordinal, len(chain))
+	for i, v := range chain {
+		ver, err := ParseVersion(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ordinals[i].core, ordinals[i].pre = ver.Ordinal()
+	}
+
+	for i := 1; i < len(ordinals); i++ {
+		prev, cur := ordinals[i-1], ordinals[i]
+		if prev.core > cur.core || (prev.core == cur.core && prev.pre >= cur.pre) {
+			t.Errorf("expected ordinal of %s %v to sort before %s %v", chain[i-1], prev, chain[i], cur)
+		}
+	}
+
+	withMeta := Semver{Major: 1, Prerelease: "rc.1", Meta: "build"}
+	if c, p := withMeta.Ordinal(); c != ordinals[7].core || p != ordinals[7].pre {
+		t.Error("expected metadata to be ignored")
+	}
+}
+
+func TestAgeRank(t *testing.T) {
+	all := []string{"1.2.0", "1.10.0", "1.9.0", "1.9.0+build.2", "2.0.0-rc.1"}
+
+	tests := []struct {
+		v        string
+		expected int
+	}{
+		{"2.0.0-rc.1", 0},
+		{"1.10.0", 1},
+		{"1.9.0", 2},
+		{"v1.9.0+build.7", 2},
+		{"1.2.0", 3},
+	}
+
+	for _, test := range tests {
+		rank, err := AgeRank(test.v, all)
+		if err != nil {
+			t.Error(err)
+		}
+		if rank != test.expected {
+			t.Errorf("expected %s to have age rank %d but got %d", test.v, test.expected, rank)
+		}
+	}
+
+	if _, err := AgeRank("1.3.0", all); err == nil {
+		t.Error("expected an error for a version that is not in the list")
+	}
+	if _, err := AgeRank("1.2.0", []string{"1.2.0", "nope"}); err == nil {
+		t.Error("expected an error for an invalid version")
+	}
+}
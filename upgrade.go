@@ -0,0 +1,617 @@
+package semver
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// PathSummary takes an upgrade path of version strings and reports how many major,
+// minor, and patch steps are crossed while walking it from the lowest to the highest
+// version.
+//
+// The versions are sorted by their major, minor, and patch components before walking.
+// Each transition contributes the magnitude of its most significant change: a move
+// from 1.4.2 to 3.0.0 adds 2 major steps, while a move from 1.0.0 to 1.3.0 adds 3 minor
+// steps. Prerelease tags and metadata are ignored.
+//
+// If any version cannot be parsed, the function returns zero counts and the error.
+//
+// Example:
+//
+//	major, minor, patch, err := PathSummary([]string{"1.0.0", "1.1.0", "2.0.0"})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(major, minor, patch) // prints 1 1 0
+func PathSummary(versions []string) (majorSteps, minorSteps, patchSteps int, err error) {
+	vers, err := parseAll(versions)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	sort.SliceStable(vers, func(i, j int) bool {
+		return compareCore(vers[i], vers[j]) < 0
+	})
+
+	for i := 1; i < len(vers); i++ {
+		prev, cur := vers[i-1], vers[i]
+		switch {
+		case cur.Major != prev.Major:
+			majorSteps += cur.Major - prev.Major
+		case cur.Minor != prev.Minor:
+			minorSteps += cur.Minor - prev.Minor
+		case cur.Patch != prev.Patch:
+			patchSteps += cur.Patch - prev.Patch
+		}
+	}
+
+	return majorSteps, minorSteps, patchSteps, nil
+}
+
+// ShouldNotify reports whether latest is far enough ahead of current to warrant an
+// update notification.
+//
+// The level argument is one of "major", "minor", or "patch". The function returns true
+// only when latest is greater than current and the most significant component that
+// differs between them is at least as significant as level. A patch-only update with a
+// level of "minor" therefore returns false.
+//
+// If either version string cannot be parsed, or level is not recognized, the function
+// returns false and the error.
+//
+// Example:
+//
+//	notify, err := ShouldNotify("1.2.3", "1.3.0", "minor")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(notify) // prints true
+func ShouldNotify(current, latest string, level string) (bool, error) {
+	threshold, err := parseLevel(level)
+	if err != nil {
+		return false, err
+	}
+
+	cur, err := parse(current)
+	if err != nil {
+		return false, err
+	}
+	lat, err := parse(latest)
+	if err != nil {
+		return false, err
+	}
+
+	if compare(lat, cur) <= 0 {
+		return false, nil
+	}
+
+	return diff(cur, lat) >= threshold, nil
+}
+
+// PositionInRange reports how far v lies between low and high as a value from 0.0 to 1.0.
+//
+// The position is interpolated on the most significant component that differs between
+// low and high: with low 1.0.0 and high 1.10.0 the minor component is used, so 1.5.3
+// lies at 0.5. When low and high share a minor line, the patch component is used
+// instead. Versions at or below low return 0.0 and versions at or above high return 1.0.
+//
+// If any version string cannot be parsed, or low is greater than high, the function
+// returns 0 and an error.
+//
+// Example:
+//
+//	pos, err := PositionInRange("1.2.5", "1.2.0", "1.2.10")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(pos) // prints 0.5
+func PositionInRange(v, low, high string) (float64, error) {
+	vers, err := parseAll([]string{v, low, high})
+	if err != nil {
+		return 0, err
+	}
+	ver, lo, hi := vers[0], vers[1], vers[2]
+
+	if compare(lo, hi) > 0 {
+		return 0, fmt.Errorf("low %q is greater than high %q", low, high)
+	}
+	if compare(ver, lo) <= 0 {
+		return 0, nil
+	}
+	if compare(ver, hi) >= 0 {
+		return 1, nil
+	}
+
+	component := func(s Semver) int { return s.Patch }
+	switch diff(lo, hi) {
+	case MajorChange:
+		component = func(s Semver) int { return s.Major }
+	case MinorChange:
+		component = func(s Semver) int { return s.Minor }
+	}
+
+	span := component(hi) - component(lo)
+	if span == 0 {
+		return 0, nil
+	}
+
+	pos := float64(component(ver)-component(lo)) / float64(span)
+	if pos < 0 {
+		return 0, nil
+	}
+	if pos > 1 {
+		return 1, nil
+	}
+	return pos, nil
+}
+
+// Compatible reports whether two version strings are compatible under caret semantics.
+//
+// Two versions are compatible when they share the left-most non-zero component of the
+// major, minor, and patch triple: 1.2.0 and 1.9.9 are compatible, 0.2.0 and 0.3.0 are
+// not, and 0.0.3 is only compatible with other 0.0.3 versions. Prerelease tags and
+// metadata are ignored.
+//
+// If there is an error parsing either version string, the function returns false and the
+// error.
+//
+// Example:
+//
+//	ok, err := Compatible("1.2.0", "1.9.9")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(ok) // prints true
+func Compatible(v1, v2 string) (bool, error) {
+	ver1, err := parse(v1)
+	if err != nil {
+		return false, err
+	}
+	ver2, err := parse(v2)
+	if err != nil {
+		return false, err
+	}
+	return compatible(ver1, ver2), nil
+}
+
+// compatible reports whether a and b fall within the same caret range.
+func compatible(a, b Semver) bool {
+	return caretUpper(a) == caretUpper(b)
+}
+
+// CompatibleWith reports whether a library at version used can stand in for the version
+// builtAgainst that a consumer was built against: used must be caret compatible with
+// builtAgainst, as in Compatible, and must not be older than it.
+//
+// If there is an error parsing either version string, the function returns false and the
+// error.
+//
+// Example:
+//
+//	ok, err := CompatibleWith("1.3.0", "1.2.0")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(ok) // prints true
+func CompatibleWith(used, builtAgainst string) (bool, error) {
+	ver1, err := parse(used)
+	if err != nil {
+		return false, err
+	}
+	ver2, err := parse(builtAgainst)
+	if err != nil {
+		return false, err
+	}
+	return compatible(ver1, ver2) && compare(ver1, ver2) >= 0, nil
+}
+
+// APIEqual reports whether two versions share the same public API contract: the same
+// major version from 1.0.0 on, or the same major and minor version below it. Unlike
+// Compatible, patch releases of a 0.0.x line are API equal to each other. This is
+// distinct from precedence equality, so 1.2.0 and 1.9.0 are API equal.
+//
+// If there is an error parsing either version string, the function returns false and the
+// error.
+//
+// Example:
+//
+//	ok, err := APIEqual("0.2.0", "0.2.5")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(ok) // prints true
+func APIEqual(v1, v2 string) (bool, error) {
+	ver1, err := parse(v1)
+	if err != nil {
+		return false, err
+	}
+	ver2, err := parse(v2)
+	if err != nil {
+		return false, err
+	}
+
+	if ver1.Major != ver2.Major {
+		return false, nil
+	}
+	return ver1.Major > 0 || ver1.Minor == ver2.Minor, nil
+}
+
+// CompatKey returns a key that is equal for all versions that Compatible considers
+// mutually compatible, which makes it suitable for grouping or caching compatibility
+// decisions.
+//
+// The key holds the components up to and including the left-most non-zero one: "1" for
+// every 1.x.y version, "0.2" for every 0.2.x version, and "0.0.3" for 0.0.3. Like
+// Compatible, it ignores prerelease tags and metadata, so 2.0.0-rc.1 shares the key of
+// 2.3.0.
+//
+// Example:
+//
+//	ver, _ := ParseVersion("1.9.9")
+//	fmt.Println(ver.CompatKey()) // prints 1
+func (s Semver) CompatKey() string {
+	switch {
+	case s.Major > 0:
+		return strconv.Itoa(s.Major)
+	case s.Minor > 0:
+		return fmt.Sprintf("0.%d", s.Minor)
+	}
+	return fmt.Sprintf("0.0.%d", s.Patch)
+}
+
+// SafeUpgrade returns the highest version in available that is caret-compatible with
+// current and greater than it.
+//
+// Prerelease versions are never chosen as upgrade targets. When no compatible newer
+// version is available, current is returned unchanged.
+//
+// If current or any available version cannot be parsed, the function returns an empty
+// string and the error.
+//
+// Example:
+//
+//	target, err := SafeUpgrade("1.2.0", []string{"1.2.5", "1.4.0", "2.0.0"})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(target) // prints 1.4.0
+func SafeUpgrade(current string, available []string) (string, error) {
+	cur, err := parse(current)
+	if err != nil {
+		return "", err
+	}
+	vers, err := parseAll(available)
+	if err != nil {
+		return "", err
+	}
+
+	candidates := []string{}
+	for i, ver := range vers {
+		if ver.Prerelease == "" && compatible(cur, ver) && compare(ver, cur) > 0 {
+			candidates = append(candidates, available[i])
+		}
+	}
+	if len(candidates) == 0 {
+		return current, nil
+	}
+
+	return Max(candidates)
+}
+
+// SafeDowngrade reports whether rolling back from from to to is a safe patch-level
+// rollback: to must be lower than from, both must share the same major and minor version,
+// and neither may be a prerelease. Metadata is ignored.
+//
+// If there is an error parsing either version string, the function returns false and the
+// error.
+//
+// Example:
+//
+//	ok, err := SafeDowngrade("1.2.5", "1.2.3")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(ok) // prints true
+func SafeDowngrade(from, to string) (bool, error) {
+	ver1, err := parse(from)
+	if err != nil {
+		return false, err
+	}
+	ver2, err := parse(to)
+	if err != nil {
+		return false, err
+	}
+
+	if ver1.Prerelease != "" || ver2.Prerelease != "" {
+		return false, nil
+	}
+	return ver1.Major == ver2.Major && ver1.Minor == ver2.Minor && compare(ver2, ver1) < 0, nil
+}
+
+// IsMinimalBump reports whether to is exactly the minimal bump of from for the given
+// change level, which is one of "major", "minor", or "patch".
+//
+// A patch change from 1.2.3 must produce 1.2.4; producing 1.3.0 is an over-bump and
+// returns false. Metadata on to is ignored.
+//
+// If either version string cannot be parsed, or changeLevel is not recognized, the
+// function returns false and the error.
+//
+// Example:
+//
+//	ok, err := IsMinimalBump("1.2.3", "1.3.0", "patch")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(ok) // prints false
+func IsMinimalBump(from, to, changeLevel string) (bool, error) {
+	ver, err := parse(from)
+	if err != nil {
+		return false, err
+	}
+	target, err := parse(to)
+	if err != nil {
+		return false, err
+	}
+
+	next, err := ver.Next(changeLevel)
+	if err != nil {
+		return false, err
+	}
+	return compare(target, next) == 0, nil
+}
+
+// Reachable reports whether to can be reached from from using only the bump levels in
+// allowed, each of which is one of "major", "minor", or "patch".
+//
+// A bump resets every lower component to zero, so reaching a non-zero lower component
+// also requires that lower level: 1.2.3 to 1.3.0 needs only "minor", while 1.2.3 to
+// 1.3.5 needs "minor" and "patch". Prerelease tags and metadata are ignored, so a version
+// with the same major, minor, and patch is always reachable. Downgrades are never
+// reachable.
+//
+// If either version string cannot be parsed, or allowed holds an unknown level, the
+// function returns false and the error.
+//
+// Example:
+//
+//	ok, err := Reachable("1.2.3", "2.0.0", []string{"minor", "patch"})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(ok) // prints false
+func Reachable(from, to string, allowed []string) (bool, error) {
+	start, err := parse(from)
+	if err != nil {
+		return false, err
+	}
+	target, err := parse(to)
+	if err != nil {
+		return false, err
+	}
+
+	permitted := make(map[Change]bool, len(allowed))
+	for _, level := range allowed {
+		lvl, err := parseLevel(level)
+		if err != nil {
+			return false, err
+		}
+		permitted[lvl] = true
+	}
+
+	if compareCore(target, start) < 0 {
+		return false, nil
+	}
+
+	switch diff(start.core(), target.core()) {
+	case MajorChange:
+		return permitted[MajorChange] &&
+			(target.Minor == 0 || permitted[MinorChange]) &&
+			(target.Patch == 0 || permitted[PatchChange]), nil
+	case MinorChange:
+		return permitted[MinorChange] && (target.Patch == 0 || permitted[PatchChange]), nil
+	case PatchChange:
+		return permitted[PatchChange], nil
+	}
+	return true, nil
+}
+
+// UpgradeUrgency rates how far current is behind latest for at-a-glance dashboards. It
+// returns "high" when a major version behind, "medium" when a minor version behind, and
+// "low" when only a patch or prerelease behind. When latest is not newer than current,
+// or differs only in metadata, it returns "none".
+//
+// If either version string cannot be parsed, the function returns an empty string and
+// the error.
+//
+// Example:
+//
+//	urgency, err := UpgradeUrgency("1.2.3", "1.4.0")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(urgency) // prints medium
+func UpgradeUrgency(current, latest string) (string, error) {
+	cur, err := parse(current)
+	if err != nil {
+		return "", err
+	}
+	newest, err := parse(latest)
+	if err != nil {
+		return "", err
+	}
+
+	if compare(newest, cur) <= 0 {
+		return "none", nil
+	}
+	switch diff(cur, newest) {
+	case MajorChange:
+		return "high", nil
+	case MinorChange:
+		return "medium", nil
+	}
+	return "low", nil
+}
+
+// DistancePhrase describes how far from is from to in words for update messages, such
+// as "2 major versions behind", "1 minor version ahead", or "3 patches behind". The
+// count is the signed difference of the most significant component that differs, and the
+// direction is that of from relative to to. Versions that differ only in their
+// prerelease tag are simply "behind" or "ahead", and versions of equal precedence are "up
+// to date".
+//
+// If either version string cannot be parsed, the function returns an empty string and
+// the error.
+//
+// Example:
+//
+//	phrase, err := DistancePhrase("1.2.0", "3.0.0")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(phrase) // prints 2 major versions behind
+func DistancePhrase(from, to string) (string, error) {
+	ver1, err := parse(from)
+	if err != nil {
+		return "", err
+	}
+	ver2, err := parse(to)
+	if err != nil {
+		return "", err
+	}
+
+	direction := "behind"
+	switch compare(ver1, ver2) {
+	case 0:
+		return "up to date", nil
+	case 1:
+		direction = "ahead"
+	}
+
+	var n int
+	var unit, units string
+	switch diff(ver1, ver2) {
+	case MajorChange:
+		n, unit, units = absDiff(ver1.Major, ver2.Major), "major version", "major versions"
+	case MinorChange:
+		n, unit, units = absDiff(ver1.Minor, ver2.Minor), "minor version", "minor versions"
+	case PatchChange:
+		n, unit, units = absDiff(ver1.Patch, ver2.Patch), "patch", "patches"
+	default:
+		return direction, nil
+	}
+
+	if n == 1 {
+		return fmt.Sprintf("1 %s %s", unit, direction), nil
+	}
+	return fmt.Sprintf("%d %s %s", n, units, direction), nil
+}
+
+// ImportanceScore rates the impact of moving from from to to as
+// weights[0]*Δmajor + weights[1]*Δminor + weights[2]*Δpatch, letting callers tune how
+// much each component matters when prioritizing upgrades.
+//
+// The deltas are absolute differences of each component, so a downgrade scores the same
+// as the matching upgrade. Prerelease tags and metadata are ignored.
+//
+// If either version string cannot be parsed, the function returns 0 and the error.
+//
+// Example:
+//
+//	score, err := ImportanceScore("1.2.3", "2.0.0", [3]int{100, 10, 1})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(score) // prints 123
+func ImportanceScore(from, to string, weights [3]int) (int, error) {
+	start, err := parse(from)
+	if err != nil {
+		return 0, err
+	}
+	target, err := parse(to)
+	if err != nil {
+		return 0, err
+	}
+
+	return weights[0]*absDiff(start.Major, target.Major) +
+		weights[1]*absDiff(start.Minor, target.Minor) +
+		weights[2]*absDiff(start.Patch, target.Patch), nil
+}
+
+// absDiff returns the absolute difference between a and b.
+func absDiff(a, b int) int {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
+// SkipsRequired returns the required versions that lie strictly between from and to,
+// which a direct upgrade from from to to would skip. The result holds the original
+// strings sorted in ascending order of precedence. When to is not newer than from,
+// nothing is skipped.
+//
+// If from, to, or any required version cannot be parsed, the function returns nil and
+// the error.
+//
+// Example:
+//
+//	skipped, err := SkipsRequired("1.0.0", "3.0.0", []string{"2.0.0", "3.1.0"})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(skipped) // prints [2.0.0]
+func SkipsRequired(from, to string, required []string) ([]string, error) {
+	start, err := parse(from)
+	if err != nil {
+		return nil, err
+	}
+	target, err := parse(to)
+	if err != nil {
+		return nil, err
+	}
+	vers, err := parseAll(required)
+	if err != nil {
+		return nil, err
+	}
+
+	skipped := byVersion{raw: []string{}}
+	for i, ver := range vers {
+		if compare(ver, start) > 0 && compare(ver, target) < 0 {
+			skipped.raw = append(skipped.raw, required[i])
+			skipped.vers = append(skipped.vers, ver)
+		}
+	}
+
+	sort.Stable(skipped)
+	return skipped.raw, nil
+}
+
+// AtLeast reports whether s meets the minimum version given by minimum.
+//
+// The major, minor, and patch components decide the result. When they equal those of
+// minimum, s satisfies the minimum only if it is a stable release, so 1.2.3-rc.1 is not
+// at least 1.2.3. Prerelease tags on minimum and metadata are ignored.
+//
+// If there is an error parsing minimum, the function returns false and the error.
+//
+// Example:
+//
+//	ver, _ := ParseVersion("1.2.3-rc.1")
+//	ok, err := ver.AtLeast("1.2.3")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(ok) // prints false
+func (s Semver) AtLeast(minimum string) (bool, error) {
+	floor, err := parse(minimum)
+	if err != nil {
+		return false, err
+	}
+
+	if result := compareCore(s, floor); result != 0 {
+		return result > 0, nil
+	}
+	return s.Prerelease == "", nil
+}
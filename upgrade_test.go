@@ -0,0 +1,474 @@
+package semver
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPathSummary(t *testing.T) {
+	tests := []struct {
+		versions []string
+		major    int
+		minor    int
+		patch    int
+	}{
+		{[]string{"1.0.0", "1.1.0", "2.0.0"}, 1, 1, 0},
+		{[]string{"2.0.0", "1.0.0", "1.1.0"}, 1, 1, 0},
+		{[]string{"1.0.0", "1.0.3", "1.2.0", "3.1.4"}, 2, 2, 3},
+		{[]string{"1.0.0-rc.1", "1.0.0", "1.0.1"}, 0, 0, 1},
+		{[]string{"1.0.0"}, 0, 0, 0},
+	}
+
+	for _, test := range tests {
+		major, minor, patch, err := PathSummary(test.versions)
+		if err != nil {
+			t.Error(err)
+		}
+		if major != test.major || minor != test.minor || patch != test.patch {
+			t.Errorf("expected %v to be %d/%d/%d but got %d/%d/%d", test.versions, test.major, test.minor, test.patch, major, minor, patch)
+		}
+	}
+
+	if _, _, _, err := PathSummary([]string{"1.0.0", "nope"}); err == nil {
+		t.Error("expected an error for an invalid version")
+	}
+}
+
+func TestShouldNotify(t *testing.T) {
+	tests := []struct {
+		current  string
+		latest   string
+		level    string
+		expected bool
+	}{
+		{"1.2.3", "1.2.4", "patch", true},
+		{"1.2.3", "1.2.4", "minor", false},
+		{"1.2.3", "1.2.4", "major", false},
+		{"1.2.3", "1.3.0", "patch", true},
+		{"1.2.3", "1.3.0", "minor", true},
+		{"1.2.3", "1.3.0", "major", false},
+		{"1.2.3", "2.0.0", "major", true},
+		{"1.3.0", "1.2.4", "patch", false},
+		{"1.2.3", "1.2.3", "patch", false},
+	}
+
+	for _, test := range tests {
+		notify, err := ShouldNotify(test.current, test.latest, test.level)
+		if err != nil {
+			t.Error(err)
+		}
+		if notify != test.expected {
+			t.Errorf("expected %s -> %s at level %s to be %t but got %t", test.current, test.latest, test.level, test.expected, notify)
+		}
+	}
+
+	if _, err := ShouldNotify("1.0.0", "1.0.1", "build"); err == nil {
+		t.Error("expected an error for an unknown level")
+	}
+}
+
+func TestPositionInRange(t *testing.T) {
+	tests := []struct {
+		v        string
+		low      string
+		high     string
+		expected float64
+	}{
+		{"1.2.0", "1.2.0", "1.2.10", 0.0},
+		{"1.2.10", "1.2.0", "1.2.10", 1.0},
+		{"1.2.5", "1.2.0", "1.2.10", 0.5},
+		{"1.5.3", "1.0.0", "1.10.0", 0.5},
+		{"0.9.0", "1.0.0", "1.10.0", 0.0},
+		{"2.0.0", "1.0.0", "1.10.0", 1.0},
+		{"2.3.0", "1.0.0", "5.0.0", 0.25},
+	}
+
+	for _, test := range tests {
+		pos, err := PositionInRange(test.v, test.low, test.high)
+		if err != nil {
+			t.Error(err)
+		}
+		if pos != test.expected {
+			t.Errorf("expected %s in [%s, %s] to be at %v but got %v", test.v, test.low, test.high, test.expected, pos)
+		}
+	}
+
+	if _, err := PositionInRange("1.0.0", "2.0.0", "1.0.0"); err == nil {
+		t.Error("expected an error when low is greater than high")
+	}
+}
+
+func TestCompatible(t *testing.T) {
+	tests := []struct {
+		v1       string
+		v2       string
+		expected bool
+	}{
+		{"1.2.0", "1.9.9", true},
+		{"1.2.0", "2.0.0", false},
+		{"0.2.0", "0.2.7", true},
+		{"0.2.0", "0.3.0", false},
+		{"0.0.3", "0.0.4", false},
+		{"1.0.0-rc.1", "1.4.0", true},
+	}
+
+	for _, test := range tests {
+		ok, err := Compatible(test.v1, test.v2)
+		if err != nil {
+			t.Error(err)
+		}
+		if ok != test.expected {
+			t.Errorf("expected %s and %s to be compatible=%t but got %t", test.v1, test.v2, test.expected, ok)
+		}
+	}
+}
+
+func TestCompatibleWith(t *testing.T) {
+	tests := []struct {
+		used         string
+		builtAgainst string
+		expected     bool
+	}{
+		{"1.3.0", "1.2.0", true},
+		{"1.2.0", "1.2.0", true},
+		{"1.1.0", "1.2.0", false},
+		{"2.0.0", "1.2.0", false},
+		{"0.2.5", "0.2.1", true},
+		{"0.3.0", "0.2.1", false},
+		{"1.2.0-rc.1", "1.2.0", false},
+	}
+
+	for _, test := range tests {
+		ok, err := CompatibleWith(test.used, test.builtAgainst)
+		if err != nil {
+			t.Error(err)
+		}
+		if ok != test.expected {
+			t.Errorf("expected %s used against %s to be compatible=%t but got %t", test.used, test.builtAgainst, test.expected, ok)
+		}
+	}
+
+	if _, err := CompatibleWith("1.2", "1.2.0"); err == nil {
+		t.Error("expected an error for an invalid version")
+	}
+}
+
+func TestSafeUpgrade(t *testing.T) {
+	tests := []struct {
+		current   string
+		available []string
+		expected  string
+	}{
+		{"1.2.0", []string{"1.2.5", "1.4.0", "2.0.0", "1.5.0-rc.1"}, "1.4.0"},
+		{"0.2.1", []string{"0.2.4", "0.3.0"}, "0.2.4"},
+		{"1.2.0", []string{"2.0.0", "3.1.0", "1.1.0"}, "1.2.0"},
+		{"1.2.0", nil, "1.2.0"},
+	}
+
+	for _, test := range tests {
+		target, err := SafeUpgrade(test.current, test.available)
+		if err != nil {
+			t.Error(err)
+		}
+		if target != test.expected {
+			t.Errorf("expected safe upgrade of %s within %v to be %s but got %s", test.current, test.available, test.expected, target)
+		}
+	}
+}
+
+func TestSafeDowngrade(t *testing.T) {
+	tests := []struct {
+		from     string
+		to       string
+		expected bool
+	}{
+		{"1.2.5", "1.2.3", true},
+		{"1.2.5+build.2", "1.2.4+build.1", true},
+		{"1.3.0", "1.2.9", false},
+		{"2.0.0", "1.9.9", false},
+		{"1.2.3", "1.2.5", false},
+		{"1.2.3", "1.2.3", false},
+		{"1.2.5-rc.1", "1.2.3", false},
+		{"1.2.5", "1.2.3-rc.1", false},
+	}
+
+	for _, test := range tests {
+		ok, err := SafeDowngrade(test.from, test.to)
+		if err != nil {
+			t.Error(err)
+		}
+		if ok != test.expected {
+			t.Errorf("expected downgrade from %s to %s to be safe=%t but got %t", test.from, test.to, test.expected, ok)
+		}
+	}
+
+	if _, err := SafeDowngrade("1.2", "1.2.0"); err == nil {
+		t.Error("expected an error for an invalid version")
+	}
+}
+
+func TestIsMinimalBump(t *testing.T) {
+	tests := []struct {
+		from     string
+		to       string
+		level    string
+		expected bool
+	}{
+		{"1.2.3", "1.2.4", "patch", true},
+		{"1.2.3", "1.3.0", "patch", false},
+		{"1.2.3", "1.3.0", "minor", true},
+		{"1.2.3", "2.0.0", "minor", false},
+		{"1.2.3", "1.3.1", "minor", false},
+		{"1.2.3", "2.0.0+build.1", "major", true},
+		{"1.2.3", "1.2.4-rc.1", "patch", false},
+	}
+
+	for _, test := range tests {
+		ok, err := IsMinimalBump(test.from, test.to, test.level)
+		if err != nil {
+			t.Error(err)
+		}
+		if ok != test.expected {
+			t.Errorf("expected %s -> %s to be a minimal %s bump=%t but got %t", test.from, test.to, test.level, test.expected, ok)
+		}
+	}
+}
+
+func TestAtLeast(t *testing.T) {
+	tests := []struct {
+		v        string
+		minimum  string
+		expected bool
+	}{
+		{"1.2.3", "1.2.3", true},
+		{"1.2.3-rc.1", "1.2.3", false},
+		{"1.2.4", "1.2.3", true},
+		{"1.2.4-rc.1", "1.2.3", true},
+		{"1.2.2", "1.2.3", false},
+		{"1.2.3+build.5", "v1.2.3", true},
+		{"1.2.3", "1.2.3-rc.1", true},
+	}
+
+	for _, test := range tests {
+		ver, err := ParseVersion(test.v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		result, err := ver.AtLeast(test.minimum)
+		if err != nil {
+			t.Error(err)
+		}
+		if result != test.expected {
+			t.Errorf("expected %s at least %s to be %v but got %v", test.v, test.minimum, test.expected, result)
+		}
+	}
+
+	if _, err := (Semver{}).AtLeast("not a version"); err == nil {
+		t.Error("expected an error for an invalid minimum")
+	}
+}
+
+func TestCompatKey(t *testing.T) {
+	tests := []struct {
+		v1       string
+		v2       string
+		expected bool
+	}{
+		{"1.2.0", "1.9.9", true},
+		{"1.2.0", "2.0.0", false},
+		{"0.2.0", "0.3.0", false},
+		{"0.2.0", "0.2.9", true},
+		{"0.0.3", "0.0.4", false},
+		{"2.0.0-rc.1", "2.3.0+build", true},
+	}
+
+	for _, test := range tests {
+		ver1, err := ParseVersion(test.v1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ver2, err := ParseVersion(test.v2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result := ver1.CompatKey() == ver2.CompatKey(); result != test.expected {
+			t.Errorf("expected keys of %s and %s to match=%v but got %q and %q", test.v1, test.v2, test.expected, ver1.CompatKey(), ver2.CompatKey())
+		}
+		if result := compatible(ver1, ver2); result != test.expected {
+			t.Errorf("expected CompatKey to agree with Compatible for %s and %s", test.v1, test.v2)
+		}
+	}
+}
+
+func TestReachable(t *testing.T) {
+	minorPatch := []string{"minor", "patch"}
+	tests := []struct {
+		from     string
+		to       string
+		allowed  []string
+		expected bool
+	}{
+		{"1.2.3", "1.3.0", minorPatch, true},
+		{"1.2.3", "1.2.9", minorPatch, true},
+		{"1.2.3", "2.0.0", minorPatch, false},
+		{"1.2.3", "2.0.0", []string{"major"}, true},
+		{"1.2.3", "2.1.0", []string{"major"}, false},
+		{"1.2.3", "1.3.5", []string{"minor"}, false},
+		{"1.2.3", "1.3.5", minorPatch, true},
+		{"1.2.3", "1.2.2", minorPatch, false},
+		{"1.2.3-rc.1", "1.2.3", nil, true},
+	}
+
+	for _, test := range tests {
+		result, err := Reachable(test.from, test.to, test.allowed)
+		if err != nil {
+			t.Error(err)
+		}
+		if result != test.expected {
+			t.Errorf("expected %s to reach %s with %v=%v but got %v", test.from, test.to, test.allowed, test.expected, result)
+		}
+	}
+
+	if _, err := Reachable("1.2.3", "1.3.0", []string{"build"}); err == nil {
+		t.Error("expected an error for an unknown level")
+	}
+}
+
+func TestSkipsRequired(t *testing.T) {
+	tests := []struct {
+		from     string
+		to       string
+		required []string
+		expected []string
+	}{
+		{"1.0.0", "3.0.0", []string{"2.0.0"}, []string{"2.0.0"}},
+		{"1.0.0", "3.0.0", []string{"2.5.0", "1.0.0", "3.0.0", "2.0.0"}, []string{"2.0.0", "2.5.0"}},
+		{"1.0.0", "2.0.0", []string{"2.0.0"}, []string{}},
+		{"3.0.0", "1.0.0", []string{"2.0.0"}, []string{}},
+	}
+
+	for _, test := range tests {
+		skipped, err := SkipsRequired(test.from, test.to, test.required)
+		if err != nil {
+			t.Error(err)
+		}
+		if !reflect.DeepEqual(skipped, test.expected) {
+			t.Errorf("expected %s to %s to skip %v but got %v", test.from, test.to, test.expected, skipped)
+		}
+	}
+
+	if _, err := SkipsRequired("1.0.0", "3.0.0", []string{"two"}); err == nil {
+		t.Error("expected an error for an invalid required version")
+	}
+}
+
+func TestUpgradeUrgency(t *testing.T) {
+	tests := []struct {
+		current  string
+		latest   string
+		expected string
+	}{
+		{"1.2.3", "2.0.0", "high"},
+		{"1.2.3", "1.4.0", "medium"},
+		{"1.2.3", "1.2.4", "low"},
+		{"1.2.3-rc.1", "1.2.3", "low"},
+		{"1.2.3", "1.2.3", "none"},
+		{"1.2.3", "1.2.3+build", "none"},
+		{"2.0.0", "1.9.0", "none"},
+	}
+
+	for _, test := range tests {
+		urgency, err := UpgradeUrgency(test.current, test.latest)
+		if err != nil {
+			t.Error(err)
+		}
+		if urgency != test.expected {
+			t.Errorf("expected urgency from %s to %s to be %s but got %s", test.current, test.latest, test.expected, urgency)
+		}
+	}
+
+	if _, err := UpgradeUrgency("1.2.3", "latest"); err == nil {
+		t.Error("expected an error for an invalid version")
+	}
+}
+
+func TestDistancePhrase(t *testing.T) {
+	tests := []struct {
+		from     string
+		to       string
+		expected string
+	}{
+		{"1.2.0", "3.0.0", "2 major versions behind"},
+		{"2.0.0", "1.9.9", "1 major version ahead"},
+		{"1.3.0", "1.2.5", "1 minor version ahead"},
+		{"1.2.0", "1.5.0", "3 minor versions behind"},
+		{"1.2.3", "1.2.4", "1 patch behind"},
+		{"1.2.9", "1.2.4", "5 patches ahead"},
+		{"1.2.3-rc.1", "1.2.3", "behind"},
+		{"1.2.3", "1.2.3+build.7", "up to date"},
+	}
+
+	for _, test := range tests {
+		phrase, err := DistancePhrase(test.from, test.to)
+		if err != nil {
+			t.Error(err)
+		}
+		if phrase != test.expected {
+			t.Errorf("expected %s to %s to be %q but got %q", test.from, test.to, test.expected, phrase)
+		}
+	}
+
+	if _, err := DistancePhrase("1.2", "1.2.0"); err == nil {
+		t.Error("expected an error for an invalid version")
+	}
+}
+
+func TestImportanceScore(t *testing.T) {
+	tests := []struct {
+		from     string
+		to       string
+		weights  [3]int
+		expected int
+	}{
+		{"1.2.3", "2.0.0", [3]int{100, 10, 1}, 123},
+		{"1.2.3", "2.0.0", [3]int{1, 0, 0}, 1},
+		{"1.2.3", "2.0.0", [3]int{0, 5, 5}, 25},
+		{"2.0.0", "1.2.3", [3]int{100, 10, 1}, 123},
+		{"1.2.3-rc.1", "1.2.3+build", [3]int{100, 10, 1}, 0},
+	}
+
+	for _, test := range tests {
+		score, err := ImportanceScore(test.from, test.to, test.weights)
+		if err != nil {
+			t.Error(err)
+		}
+		if score != test.expected {
+			t.Errorf("expected %s to %s with %v to score %d but got %d", test.from, test.to, test.weights, test.expected, score)
+		}
+	}
+}
+
+func TestAPIEqual(t *testing.T) {
+	tests := []struct {
+		v1       string
+		v2       string
+		expected bool
+	}{
+		{"1.2.0", "1.9.0", true},
+		{"2.0.0", "1.9.0", false},
+		{"0.2.0", "0.2.5", true},
+		{"0.2.0", "0.3.0", false},
+		{"0.0.1", "0.0.2", true},
+		{"1.0.0-rc.1", "1.4.0+build", true},
+	}
+
+	for _, test := range tests {
+		ok, err := APIEqual(test.v1, test.v2)
+		if err != nil {
+			t.Error(err)
+		}
+		if ok != test.expected {
+			t.Errorf("expected %s and %s to be API equal=%v but got %v", test.v1, test.v2, test.expected, ok)
+		}
+	}
+}